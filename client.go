@@ -9,25 +9,61 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
 	"time"
 )
 
 const (
 	API_URL     = "https://botapi.tamtam.chat"
 	GET_TIMEOUT = time.Second * 3
+	// POLL_TIMEOUT is the default long polling timeout (in seconds) used by the server
+	POLL_TIMEOUT = 30
+	// REDACTED_TOKEN replaces the access token in the errors returned by the library
+	REDACTED_TOKEN = "REDACTED"
 )
 
 type Bot struct {
-	token  string
-	client *http.Client
+	// mu guards interceptors
+	mu           sync.RWMutex
+	interceptors []Interceptor
+	token        string
+	client       *http.Client
+	apiUrl       string
+	headers      http.Header
+	userAgent    string
+	timeout      time.Duration
+	retryPolicy  RetryPolicy
+	logger       Logger
+	tokenHeader  bool
 	*BotInfo
 }
 
 type BotOpts struct {
 	Client                   *http.Client
 	DisableTokenVerification bool
+	// APIURL is the base URL of the Bot API, defaults to API_URL.
+	// It can be pointed to a proxy, a mirror or a mock server.
+	APIURL string
+	// Headers are sent with every request made to the Bot API.
+	Headers http.Header
+	// UserAgent overrides the User-Agent header of the requests.
+	UserAgent string
+	// Timeout is applied to the requests whose context has no deadline, defaults to GET_TIMEOUT.
+	Timeout time.Duration
+	// RetryPolicy decides which failed requests are retried, defaults to a BackoffRetryPolicy.
+	// Use NoRetry to disable retries.
+	RetryPolicy RetryPolicy
+	// Logger logs the requests, responses and retries, defaults to DefaultLogger.
+	Logger Logger
+	// Interceptors wrap every API call made by the bot, see Bot.Use.
+	Interceptors []Interceptor
+	// TokenInHeader sends the access token in the Authorization header
+	// instead of the access_token query parameter, keeping it out of the URLs.
+	TokenInHeader bool
 }
 
+// NewBot creates a new Bot with the provided access token.
 func NewBot(token string, opts *BotOpts) (*Bot, error) {
 	if opts == nil {
 		opts = new(BotOpts)
@@ -35,9 +71,29 @@ func NewBot(token string, opts *BotOpts) (*Bot, error) {
 	if opts.Client == nil {
 		opts.Client = new(http.Client)
 	}
-	b := Bot{
-		token:  token,
-		client: opts.Client,
+	if opts.APIURL == "" {
+		opts.APIURL = API_URL
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = GET_TIMEOUT
+	}
+	if opts.RetryPolicy == nil {
+		opts.RetryPolicy = new(BackoffRetryPolicy)
+	}
+	if opts.Logger == nil {
+		opts.Logger = DefaultLogger
+	}
+	b := &Bot{
+		interceptors: opts.Interceptors,
+		token:        token,
+		client:       opts.Client,
+		apiUrl:       strings.TrimSuffix(opts.APIURL, "/"),
+		headers:      opts.Headers.Clone(),
+		userAgent:    opts.UserAgent,
+		timeout:      opts.Timeout,
+		retryPolicy:  opts.RetryPolicy,
+		logger:       opts.Logger,
+		tokenHeader:  opts.TokenInHeader,
 	}
 	if !opts.DisableTokenVerification {
 		info, err := b.GetInfo()
@@ -46,39 +102,163 @@ func NewBot(token string, opts *BotOpts) (*Bot, error) {
 		}
 		b.BotInfo = info
 	}
-	return &b, nil
+	return b, nil
 }
 
+// MakeRequest sends a request to the given Bot API method and returns the raw response body.
 func (b *Bot) MakeRequest(httpMethod string, method string, params url.Values, body []byte) (io.ReadCloser, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), GET_TIMEOUT)
-	defer cancel()
-	return b.makeRequestWithContext(httpMethod, ctx, method, params, body)
+	return b.MakeRequestWithContext(context.Background(), httpMethod, method, params, body)
+}
+
+// MakeRequestWithContext is the context-aware version of MakeRequest.
+// The request is bound to ctx, if ctx has no deadline then the timeout of the bot is applied to each attempt.
+// Failed attempts are retried according to the retry policy of the bot.
+func (b *Bot) MakeRequestWithContext(ctx context.Context, httpMethod string, method string, params url.Values, body []byte) (io.ReadCloser, error) {
+	call := b.newAPICall(httpMethod, method, params, body, nil)
+	if err := b.invoke(ctx, call); err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(call.Response)), nil
+}
+
+// execute makes the request of the call, retrying the failed attempts, and decodes the response.
+func (b *Bot) execute(ctx context.Context, call *APICall) error {
+	for attempt := 1; ; attempt++ {
+		b.logger.Debug("sending request", "method", call.Method, "http_method", call.HTTPMethod, "attempt", attempt)
+		start := time.Now()
+		bs, resp, err := b.doRequest(ctx, call)
+		if resp != nil {
+			b.logger.Debug("received response", "method", call.Method, "status", resp.StatusCode, "duration", time.Since(start))
+		}
+		if err == nil {
+			call.Response = bs
+			if call.Result == nil {
+				return nil
+			}
+			if err := json.Unmarshal(bs, call.Result); err != nil {
+				return &DecodeError{Method: call.Method, Err: err}
+			}
+			return nil
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+		delay, ok := b.retryPolicy.Retry(attempt, call.HTTPMethod, resp, err)
+		if !ok {
+			b.logger.Debug("request failed", "method", call.Method, "attempt", attempt, "error", err)
+			return err
+		}
+		b.logger.Warn("retrying request", "method", call.Method, "attempt", attempt, "delay", delay, "error", err)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
 }
 
-func (b *Bot) makeRequestWithContext(httpMethod string, ctx context.Context, method string, params url.Values, body []byte) (io.ReadCloser, error) {
-	r, err := http.NewRequest(httpMethod, fmt.Sprintf("%s/%s", API_URL, method), safeBody(body))
+// doRequest makes a single attempt of the request, the response body is fully read
+// since ctx may be cancelled as soon as we return.
+func (b *Bot) doRequest(ctx context.Context, call *APICall) ([]byte, *http.Response, error) {
+	if _, ok := ctx.Deadline(); !ok && !call.upload {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, b.timeout)
+		defer cancel()
+	}
+	r, err := http.NewRequestWithContext(ctx, call.HTTPMethod, call.URL, safeBody(call.Body))
 	if err != nil {
-		return nil, fmt.Errorf("failed to build GET request to %s: %w", method, err)
+		return nil, nil, fmt.Errorf("failed to build %s request to %s: %w", call.HTTPMethod, call.Method, err)
+	}
+	for k, v := range call.Header {
+		r.Header[k] = v
+	}
+	// the URL may already carry a query, e.g. the signed URLs of the upload servers
+	query := r.URL.Query()
+	for k, v := range call.Params {
+		query[k] = v
 	}
-	params.Add("access_token", b.token)
-	r.URL.RawQuery = params.Encode()
+	switch {
+	case call.upload:
+	case b.tokenHeader:
+		r.Header.Set("Authorization", b.token)
+	default:
+		query.Set("access_token", b.token)
+	}
+	r.URL.RawQuery = query.Encode()
 	resp, err := b.client.Do(r)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute GET request to %s: %w", method, err)
+		return nil, nil, &TransportError{Method: call.Method, Err: b.redactError(err)}
+	}
+	defer resp.Body.Close()
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp, &TransportError{Method: call.Method, Err: b.redactError(err)}
 	}
 	if resp.StatusCode != 200 {
-		defer resp.Body.Close()
-		bytes, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, errors.New("unknown error occured while making the request")
+		apiErr := newAPIError(call.Method, resp, bs)
+		if b.token != "" {
+			apiErr.Message = strings.ReplaceAll(apiErr.Message, b.token, REDACTED_TOKEN)
 		}
-		var tamtamError Error
-		if err := json.Unmarshal(bytes, &tamtamError); err != nil {
-			return nil, errors.New(string(bytes))
+		return nil, resp, apiErr
+	}
+	return bs, resp, nil
+}
+
+// redactError removes the access token from the error, notably from the URL
+// of the *url.Error returned by http.Client.Do.
+func (b *Bot) redactError(err error) error {
+	if b.token == "" || !strings.Contains(err.Error(), b.token) {
+		return err
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		redacted := *urlErr
+		redacted.URL = strings.ReplaceAll(urlErr.URL, b.token, REDACTED_TOKEN)
+		redacted.Err = b.redactError(urlErr.Err)
+		if !strings.Contains(redacted.Error(), b.token) {
+			return &redacted
 		}
-		return nil, &tamtamError
 	}
-	return resp.Body, nil
+	return &redactedError{msg: strings.ReplaceAll(err.Error(), b.token, REDACTED_TOKEN), err: err}
+}
+
+// redactedError hides the message of an error which contains the access token.
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string {
+	return e.msg
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// newAPIError builds the error from an unsuccessful response, if the body is not
+// a JSON error then it is used as the message.
+func newAPIError(method string, resp *http.Response, body []byte) *APIError {
+	apiErr := APIError{}
+	if err := json.Unmarshal(body, &apiErr); err != nil || (apiErr.Code == "" && apiErr.Message == "") {
+		apiErr = APIError{Message: strings.TrimSpace(string(body))}
+	}
+	apiErr.StatusCode = resp.StatusCode
+	apiErr.Method = method
+	apiErr.RequestID = resp.Header.Get("X-Request-Id")
+	return &apiErr
+}
+
+// GetLogger returns the logger used by the bot.
+func (b *Bot) GetLogger() Logger {
+	return b.logger
+}
+
+// GetRetryPolicy returns the retry policy used by the bot.
+func (b *Bot) GetRetryPolicy() RetryPolicy {
+	return b.retryPolicy
 }
 
 func safeBody(body []byte) io.Reader {
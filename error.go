@@ -1,40 +1,108 @@
 package gottbot
 
 import (
+	"errors"
 	"fmt"
 )
 
 var (
-	AttachmentNotReadyError = &Error{
-		Code:    "attachment.not.ready",
-		Message: "Key: errors.process.attachment.file.not.processed",
+	NotFoundError = &APIError{
+		Code: "not.found",
 	}
-	InvalidPhotoPayloadError = &Error{
+	AccessDeniedError = &APIError{
+		Code: "access.denied",
+	}
+	TooManyRequestsError = &APIError{
+		Code: "too.many.requests",
+	}
+	VerifyTokenError = &APIError{
+		Code: "verify.token",
+	}
+	AttachmentNotReadyError = &APIError{
+		Code: "attachment.not.ready",
+	}
+	InvalidPhotoPayloadError = &APIError{
 		Code:    "proto.payload",
 		Message: "No `photos`, `url` or `token` provided. Check payload.",
 	}
 )
 
-// Error Server returns this if there was an exception to your request
-type Error struct {
+// APIError Server returns this if there was an exception to your request
+type APIError struct {
+	// StatusCode HTTP status code of the response
+	StatusCode int `json:"-"`
+
 	// Code Error code
 	Code string `json:"code"`
 
 	// Message Human-readable description
 	Message string `json:"message"`
+
+	// Method Bot API method which returned the error
+	Method string `json:"-"`
+
+	// RequestID Identifier of the request, if the server provided one
+	RequestID string `json:"-"`
 }
 
-func (e *Error) Error() string {
-	return fmt.Sprintf("An error occured with the code '%s' due to '%s'", e.Code, e.Message)
+// Error is the former name of APIError, kept for compatibility.
+type Error = APIError
+
+func (e *APIError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("%s failed with the status %d due to '%s'", e.Method, e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("%s failed with the code '%s' due to '%s'", e.Method, e.Code, e.Message)
 }
 
-func EqErrors(err error, target *Error) bool {
-	unwrappedErr, ok := err.(*Error)
+// Is reports whether the error matches the target *APIError.
+// Only the non-zero fields among Code, Message and StatusCode of the target are compared,
+// so the sentinel errors of this package match by their code.
+func (e *APIError) Is(target error) bool {
+	t, ok := target.(*APIError)
 	if !ok {
 		return false
 	}
-	if target.Message == "" {
-		return unwrappedErr.Code == target.Code
+	if t.Code == "" && t.Message == "" && t.StatusCode == 0 {
+		return false
 	}
-	return unwrappedErr.Code == target.Code && unwrappedErr.Message == target.Message
+	return (t.Code == "" || t.Code == e.Code) &&
+		(t.Message == "" || t.Message == e.Message) &&
+		(t.StatusCode == 0 || t.StatusCode == e.StatusCode)
+}
+
+// TransportError is returned when a request could not be delivered to the server
+// or its response could not be read.
+type TransportError struct {
+	// Method Bot API method of the request
+	Method string
+	Err    error
+}
+
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("failed to execute request to %s: %s", e.Method, e.Err.Error())
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeError is returned when a successful response could not be decoded.
+type DecodeError struct {
+	// Method Bot API method of the request
+	Method string
+	Err    error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("failed to decode response of %s: %s", e.Method, e.Err.Error())
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// EqErrors reports whether err matches the target, it is equivalent to errors.Is(err, target).
+func EqErrors(err error, target *APIError) bool {
+	return errors.Is(err, target)
 }
@@ -0,0 +1,65 @@
+package ext
+
+import (
+	"strings"
+	"unicode"
+)
+
+// CommandArgs are the arguments of the command being handled.
+type CommandArgs struct {
+	// Command is the name of the command as sent, without the prefix and the bot mention.
+	Command string
+	// Mention is the bot username the command was addressed to, e.g. "mybot" for /start@mybot.
+	Mention string
+	// Raw is the text following the command, with the surrounding spaces trimmed.
+	Raw string
+}
+
+// Fields splits the arguments around the spaces.
+func (a *CommandArgs) Fields() []string {
+	return strings.Fields(a.Raw)
+}
+
+// Split splits the arguments around the spaces like Fields, but keeps the text enclosed
+// in double or single quotes as a single argument. A quote only opens at the start of an argument,
+// so apostrophes within words are kept, and an unterminated quote extends to the end of the text.
+// A backslash escapes the next character.
+func (a *CommandArgs) Split() []string {
+	var (
+		args    []string
+		current strings.Builder
+		inArg   bool
+		quote   rune
+		escaped bool
+	)
+	for _, r := range a.Raw {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped, inArg = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case !inArg && (r == '"' || r == '\''):
+			quote, inArg = r, true
+		case unicode.IsSpace(r):
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args
+}
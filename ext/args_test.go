@@ -0,0 +1,36 @@
+package ext
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCommandArgs(t *testing.T) {
+	tests := []struct {
+		raw    string
+		fields []string
+		split  []string
+	}{
+		{raw: "", fields: []string{}, split: nil},
+		{raw: "a  b\tc", fields: []string{"a", "b", "c"}, split: []string{"a", "b", "c"}},
+		{
+			raw:    `don't stop "a b" c\ d`,
+			fields: []string{"don't", "stop", `"a`, `b"`, `c\`, "d"},
+			split:  []string{"don't", "stop", "a b", "c d"},
+		},
+		{raw: `'single quoted' "it's"`, fields: []string{"'single", "quoted'", `"it's"`}, split: []string{"single quoted", "it's"}},
+		{raw: `say \"hi\" \\`, fields: []string{"say", `\"hi\"`, `\\`}, split: []string{"say", `"hi"`, `\`}},
+		{raw: `"" x`, fields: []string{`""`, "x"}, split: []string{"", "x"}},
+		{raw: `a "unterminated quote`, fields: []string{"a", `"unterminated`, "quote"}, split: []string{"a", "unterminated quote"}},
+		{raw: `rock'n'roll 'n`, fields: []string{"rock'n'roll", "'n"}, split: []string{"rock'n'roll", "n"}},
+	}
+	for _, tt := range tests {
+		args := &CommandArgs{Raw: tt.raw}
+		if fields := args.Fields(); !reflect.DeepEqual(fields, tt.fields) {
+			t.Errorf("Fields(%q) = %q, want %q", tt.raw, fields, tt.fields)
+		}
+		if split := args.Split(); !reflect.DeepEqual(split, tt.split) {
+			t.Errorf("Split(%q) = %q, want %q", tt.raw, split, tt.split)
+		}
+	}
+}
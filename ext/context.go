@@ -5,71 +5,132 @@ import (
 )
 
 // Context contains the important data of the current update.
+// The same Context is shared by all the handlers and middlewares processing the update.
 type Context struct {
+	// EffectiveUser is the user who caused the update, e.g. the user who pressed the button of a callback.
 	EffectiveUser    *gottbot.User
 	EffectiveMessage *gottbot.Message
-	EffectiveQuery   *gottbot.Callback
-	EffectiveChatId  int64
-	Data             map[string]any
+	// EffectiveSender is the sender of EffectiveMessage, it can differ from EffectiveUser.
+	EffectiveSender *gottbot.User
+	EffectiveQuery  *gottbot.Callback
+	// EffectiveChat is the chat of the update, only its ChatId is set for the updates without a message.
+	EffectiveChat   *gottbot.Recipient
+	EffectiveChatId int64
+	// UserLocale is the locale of the user in IETF BCP 47 format, if sent with the update.
+	UserLocale string
+	// Timestamp is the Unix-time when the event of the update has occurred.
+	Timestamp int64
+	// Args are the parsed arguments of the command, set by the command handlers
+	// only while their callback runs.
+	Args *CommandArgs
+	Data map[string]any
 	*gottbot.Update
 }
 
+// NewContext creates the Context of the update, the fields which are not available
+// for the type of the update are left empty.
 func NewContext(u *gottbot.Update) *Context {
 	ctx := &Context{
+		Data:   make(map[string]any),
 		Update: u,
 	}
+	if u == nil {
+		return ctx
+	}
 	switch {
 	case u.MessageCreated != nil:
-		ctx.EffectiveMessage = u.MessageCreated.Message
-		ctx.EffectiveUser = u.MessageCreated.Message.Sender
-		ctx.EffectiveChatId = u.MessageCreated.Message.Recipient.ChatId
+		ctx.setMessage(u.MessageCreated.Message)
+		ctx.UserLocale = u.MessageCreated.UserLocale
+		ctx.Timestamp = u.MessageCreated.Timestamp
 
 	case u.MessageCallback != nil:
+		// the message can be nil if it was deleted, the user is the one who pressed the button
+		ctx.setMessage(u.MessageCallback.Message)
 		ctx.EffectiveQuery = u.MessageCallback.Callback
-		ctx.EffectiveMessage = u.MessageCallback.Message
-		ctx.EffectiveUser = u.MessageCallback.Message.Sender
-		ctx.EffectiveChatId = u.MessageCallback.Message.Recipient.ChatId
+		if u.MessageCallback.Callback != nil {
+			ctx.EffectiveUser = u.MessageCallback.Callback.User
+		} else {
+			ctx.EffectiveUser = nil
+		}
+		ctx.UserLocale = u.MessageCallback.UserLocale
+		ctx.Timestamp = u.MessageCallback.Timestamp
 
 	case u.MessageEdited != nil:
-		ctx.EffectiveMessage = u.MessageEdited.Message
-		ctx.EffectiveUser = u.MessageEdited.Message.Sender
-		ctx.EffectiveChatId = u.MessageEdited.Message.Recipient.ChatId
+		ctx.setMessage(u.MessageEdited.Message)
+		ctx.Timestamp = u.MessageEdited.Timestamp
+
+	case u.MessageRemoved != nil:
+		ctx.setChat(u.MessageRemoved.ChatId)
+		ctx.Timestamp = u.MessageRemoved.Timestamp
 
 	case u.BotAdded != nil:
 		ctx.EffectiveUser = u.BotAdded.User
-		ctx.EffectiveChatId = u.BotAdded.ChatId
+		ctx.setChat(u.BotAdded.ChatId)
+		ctx.Timestamp = u.BotAdded.Timestamp
 
 	case u.BotRemoved != nil:
 		ctx.EffectiveUser = u.BotRemoved.User
-		ctx.EffectiveChatId = u.BotAdded.ChatId
+		ctx.setChat(u.BotRemoved.ChatId)
+		ctx.Timestamp = u.BotRemoved.Timestamp
 
 	case u.BotStarted != nil:
 		ctx.EffectiveUser = u.BotStarted.User
-		ctx.EffectiveChatId = u.BotStarted.ChatId
+		ctx.setChat(u.BotStarted.ChatId)
+		ctx.UserLocale = u.BotStarted.UserLocale
+		ctx.Timestamp = u.BotStarted.Timestamp
 
 	case u.UserAdded != nil:
 		ctx.EffectiveUser = u.UserAdded.User
-		ctx.EffectiveChatId = u.UserAdded.ChatId
+		ctx.setChat(u.UserAdded.ChatId)
+		ctx.Timestamp = u.UserAdded.Timestamp
 
 	case u.UserRemoved != nil:
 		ctx.EffectiveUser = u.UserRemoved.User
-		ctx.EffectiveChatId = u.UserRemoved.ChatId
+		ctx.setChat(u.UserRemoved.ChatId)
+		ctx.Timestamp = u.UserRemoved.Timestamp
 
 	case u.MessageConstructed != nil:
-		ctx.EffectiveMessage = u.MessageConstructed.Message
-		ctx.EffectiveUser = u.MessageConstructed.Message.Sender
-		ctx.EffectiveChatId = u.MessageConstructed.Message.Recipient.ChatId
+		ctx.setMessage(u.MessageConstructed.Message)
+		ctx.Timestamp = u.MessageConstructed.Timestamp
 
 	case u.MessageConstructionRequest != nil:
 		ctx.EffectiveUser = u.MessageConstructionRequest.User
+		ctx.UserLocale = u.MessageConstructionRequest.UserLocale
+		ctx.Timestamp = u.MessageConstructionRequest.Timestamp
 
 	case u.ChatTitleChanged != nil:
 		ctx.EffectiveUser = u.ChatTitleChanged.User
-		ctx.EffectiveChatId = u.ChatTitleChanged.ChatId
+		ctx.setChat(u.ChatTitleChanged.ChatId)
+		ctx.Timestamp = u.ChatTitleChanged.Timestamp
 
 	case u.MessageChatCreated != nil:
-		ctx.EffectiveChatId = u.MessageChatCreated.Chat.ChatId
+		if chat := u.MessageChatCreated.Chat; chat != nil {
+			ctx.EffectiveChat = &gottbot.Recipient{ChatId: chat.ChatId, ChatType: chat.Type}
+			ctx.EffectiveChatId = chat.ChatId
+		}
+		ctx.Timestamp = u.MessageChatCreated.Timestamp
+
+	case u.Unknown != nil:
+		ctx.Timestamp = u.Unknown.Timestamp
 
 	}
 	return ctx
 }
+
+// setMessage fills the fields derived from the message, m can be nil.
+func (ctx *Context) setMessage(m *gottbot.Message) {
+	if m == nil {
+		return
+	}
+	ctx.EffectiveMessage = m
+	ctx.EffectiveSender = m.Sender
+	ctx.EffectiveUser = m.Sender
+	ctx.EffectiveChat = &m.Recipient
+	ctx.EffectiveChatId = m.Recipient.ChatId
+}
+
+// setChat fills the chat fields of the updates carrying only the chat id.
+func (ctx *Context) setChat(chatId int64) {
+	ctx.EffectiveChat = &gottbot.Recipient{ChatId: chatId}
+	ctx.EffectiveChatId = chatId
+}
@@ -0,0 +1,218 @@
+package ext
+
+import (
+	"testing"
+
+	"github.com/anonyindian/gottbot"
+)
+
+func TestNewContext(t *testing.T) {
+	sender := &gottbot.User{UserId: 1, Name: "sender"}
+	user := &gottbot.User{UserId: 2, Name: "user"}
+	message := &gottbot.Message{
+		Sender:    sender,
+		Recipient: gottbot.Recipient{ChatId: 10, ChatType: "chat"},
+		Body:      gottbot.MessageBody{Mid: "mid", Text: "text"},
+	}
+	messageChat := &gottbot.Recipient{ChatId: 10, ChatType: "chat"}
+	chat := &gottbot.Recipient{ChatId: 20}
+
+	tests := []struct {
+		name   string
+		update *gottbot.Update
+		// expected fields of the context
+		user      *gottbot.User
+		sender    *gottbot.User
+		chat      *gottbot.Recipient
+		chatId    int64
+		locale    string
+		timestamp int64
+	}{
+		{
+			name:   "nil update",
+			update: nil,
+		},
+		{
+			name: "message created",
+			update: &gottbot.Update{
+				Type:           gottbot.UpdateTypeMessageCreated,
+				MessageCreated: &gottbot.MessageCreated{Timestamp: 1, Message: message, UserLocale: "en"},
+			},
+			user: sender, sender: sender, chat: messageChat, chatId: 10, locale: "en", timestamp: 1,
+		},
+		{
+			name: "message created without message",
+			update: &gottbot.Update{
+				Type:           gottbot.UpdateTypeMessageCreated,
+				MessageCreated: &gottbot.MessageCreated{Timestamp: 1},
+			},
+			timestamp: 1,
+		},
+		{
+			name: "message callback",
+			update: &gottbot.Update{
+				Type: gottbot.UpdateTypeMessageCallback,
+				MessageCallback: &gottbot.MessageCallback{
+					Timestamp:  2,
+					Callback:   &gottbot.Callback{User: user, Payload: "payload"},
+					Message:    message,
+					UserLocale: "ru",
+				},
+			},
+			user: user, sender: sender, chat: messageChat, chatId: 10, locale: "ru", timestamp: 2,
+		},
+		{
+			name: "message callback with deleted message",
+			update: &gottbot.Update{
+				Type: gottbot.UpdateTypeMessageCallback,
+				MessageCallback: &gottbot.MessageCallback{
+					Timestamp: 2,
+					Callback:  &gottbot.Callback{User: user},
+				},
+			},
+			user: user, timestamp: 2,
+		},
+		{
+			name: "message callback without callback",
+			update: &gottbot.Update{
+				Type:            gottbot.UpdateTypeMessageCallback,
+				MessageCallback: &gottbot.MessageCallback{Timestamp: 2, Message: message},
+			},
+			sender: sender, chat: messageChat, chatId: 10, timestamp: 2,
+		},
+		{
+			name: "message edited",
+			update: &gottbot.Update{
+				Type:          gottbot.UpdateTypeMessageEdited,
+				MessageEdited: &gottbot.MessageEdited{Timestamp: 3, Message: message},
+			},
+			user: sender, sender: sender, chat: messageChat, chatId: 10, timestamp: 3,
+		},
+		{
+			name: "message removed",
+			update: &gottbot.Update{
+				Type:           gottbot.UpdateTypeMessageRemoved,
+				MessageRemoved: &gottbot.MessageRemoved{Timestamp: 4, MessageId: "mid", ChatId: 20, UserId: 2},
+			},
+			chat: chat, chatId: 20, timestamp: 4,
+		},
+		{
+			name: "bot added",
+			update: &gottbot.Update{
+				Type:     gottbot.UpdateTypeBotAdded,
+				BotAdded: &gottbot.BotAdded{Timestamp: 5, ChatId: 20, User: user},
+			},
+			user: user, chat: chat, chatId: 20, timestamp: 5,
+		},
+		{
+			// the BotAdded field is nil, it must not be read
+			name: "bot removed",
+			update: &gottbot.Update{
+				Type:       gottbot.UpdateTypeBotRemoved,
+				BotRemoved: &gottbot.BotRemoved{Timestamp: 6, ChatId: 20, User: user},
+			},
+			user: user, chat: chat, chatId: 20, timestamp: 6,
+		},
+		{
+			name: "bot started",
+			update: &gottbot.Update{
+				Type:       gottbot.UpdateTypeBotStarted,
+				BotStarted: &gottbot.BotStarted{Timestamp: 7, ChatId: 20, User: user, UserLocale: "de"},
+			},
+			user: user, chat: chat, chatId: 20, locale: "de", timestamp: 7,
+		},
+		{
+			name: "user added",
+			update: &gottbot.Update{
+				Type:      gottbot.UpdateTypeUserAdded,
+				UserAdded: &gottbot.UserAdded{Timestamp: 8, ChatId: 20, User: user},
+			},
+			user: user, chat: chat, chatId: 20, timestamp: 8,
+		},
+		{
+			name: "user removed",
+			update: &gottbot.Update{
+				Type:        gottbot.UpdateTypeUserRemoved,
+				UserRemoved: &gottbot.UserRemoved{Timestamp: 9, ChatId: 20, User: user},
+			},
+			user: user, chat: chat, chatId: 20, timestamp: 9,
+		},
+		{
+			name: "chat title changed",
+			update: &gottbot.Update{
+				Type:             gottbot.UpdateTypeChatTitleChanged,
+				ChatTitleChanged: &gottbot.ChatTitleChanged{Timestamp: 10, ChatId: 20, User: user, Title: "title"},
+			},
+			user: user, chat: chat, chatId: 20, timestamp: 10,
+		},
+		{
+			name: "message chat created",
+			update: &gottbot.Update{
+				Type:               gottbot.UpdateTypeMessageChatCreated,
+				MessageChatCreated: &gottbot.MessageChatCreated{Timestamp: 11, Chat: &gottbot.Chat{ChatId: 30, Type: "chat"}},
+			},
+			chat: &gottbot.Recipient{ChatId: 30, ChatType: "chat"}, chatId: 30, timestamp: 11,
+		},
+		{
+			name: "message chat created without chat",
+			update: &gottbot.Update{
+				Type:               gottbot.UpdateTypeMessageChatCreated,
+				MessageChatCreated: &gottbot.MessageChatCreated{Timestamp: 11},
+			},
+			timestamp: 11,
+		},
+		{
+			name: "message construction request",
+			update: &gottbot.Update{
+				Type:                       gottbot.UpdateTypeMessageConstructionRequest,
+				MessageConstructionRequest: &gottbot.MessageConstructionRequest{Timestamp: 12, User: user, UserLocale: "fr"},
+			},
+			user: user, locale: "fr", timestamp: 12,
+		},
+		{
+			name: "message constructed",
+			update: &gottbot.Update{
+				Type:               gottbot.UpdateTypeMessageConstructed,
+				MessageConstructed: &gottbot.MessageConstructed{Timestamp: 13, Message: message},
+			},
+			user: sender, sender: sender, chat: messageChat, chatId: 10, timestamp: 13,
+		},
+		{
+			name: "unknown",
+			update: &gottbot.Update{
+				Type:    "dialog_muted",
+				Unknown: &gottbot.RawUpdate{Timestamp: 14, UpdateType: "dialog_muted"},
+			},
+			timestamp: 14,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := NewContext(tt.update)
+			if ctx.Update != tt.update {
+				t.Errorf("Update = %p, want %p", ctx.Update, tt.update)
+			}
+			if ctx.Data == nil {
+				t.Error("Data is nil")
+			}
+			if ctx.EffectiveUser != tt.user {
+				t.Errorf("EffectiveUser = %+v, want %+v", ctx.EffectiveUser, tt.user)
+			}
+			if ctx.EffectiveSender != tt.sender {
+				t.Errorf("EffectiveSender = %+v, want %+v", ctx.EffectiveSender, tt.sender)
+			}
+			if (ctx.EffectiveChat == nil) != (tt.chat == nil) || ctx.EffectiveChat != nil && *ctx.EffectiveChat != *tt.chat {
+				t.Errorf("EffectiveChat = %+v, want %+v", ctx.EffectiveChat, tt.chat)
+			}
+			if ctx.EffectiveChatId != tt.chatId {
+				t.Errorf("EffectiveChatId = %d, want %d", ctx.EffectiveChatId, tt.chatId)
+			}
+			if ctx.UserLocale != tt.locale {
+				t.Errorf("UserLocale = %q, want %q", ctx.UserLocale, tt.locale)
+			}
+			if ctx.Timestamp != tt.timestamp {
+				t.Errorf("Timestamp = %d, want %d", ctx.Timestamp, tt.timestamp)
+			}
+		})
+	}
+}
@@ -3,7 +3,10 @@ package ext
 import (
 	"errors"
 	"fmt"
+	"reflect"
+	"runtime/debug"
 	"sort"
+	"sync"
 
 	"github.com/anonyindian/gottbot"
 )
@@ -12,6 +15,8 @@ var (
 	EndGroups        = errors.New("end")
 	ContinueGroup    = errors.New("continue")
 	SkipCurrentGroup = errors.New("skip")
+
+	DuplicateHandlerIDError = errors.New("a handler with the same id is already registered")
 )
 
 type Dispatcher interface {
@@ -19,43 +24,211 @@ type Dispatcher interface {
 	AddHandlerToGroup(group int, handler Handler) HandlerID
 	AddHandler(handler Handler) HandlerID
 	RemoveHandler(id HandlerID) bool
+	ListHandlers() []HandlerInfo
+	LookupHandler(id HandlerID) (HandlerInfo, bool)
+	EnableHandler(id HandlerID) bool
+	DisableHandler(id HandlerID) bool
 	Run(bot *gottbot.Bot, updateChan chan *gottbot.Update)
 }
 
+// HandlerInfo describes a handler registered in a dispatcher.
+type HandlerInfo struct {
+	ID      HandlerID
+	Group   int
+	Handler Handler
+	// Enabled is false if the handler has been disabled with DisableHandler.
+	Enabled bool
+}
+
+// DefaultMaxRoutines is the default number of updates processed concurrently by the GeneralDispatcher.
+const DefaultMaxRoutines = 50
+
 // GeneralDispatcher is the default dispatcher.
+//
+// Handlers can be added and removed at any time, even from inside other handlers.
+// Such changes take effect from the next update, the updates already being processed
+// keep using the handlers they started with.
 type GeneralDispatcher struct {
+	// mu guards handlerGroups, handlerMap, disabled and middlewares.
+	// handlerGroups and handlerMap must always have the same groups.
+	mu sync.RWMutex
+	// handlerGroups are kept sorted in ascending order, the order they are executed in.
 	handlerGroups []int
 	handlerMap    map[int][]Handler
+	disabled      map[HandlerID]struct{}
+	middlewares   []Middleware
 	ErrorHandler  func(*gottbot.Bot, *gottbot.Update, error)
+	logger        gottbot.Logger
+	// limiter bounds the number of updates being processed at once.
+	limiter      chan struct{}
+	chatOrdering bool
+}
+
+// Optional fields for the NewDispatcherWithOpts
+type DispatcherOpts struct {
+	ErrorHandler func(*gottbot.Bot, *gottbot.Update, error)
+	// MaxRoutines is the maximum number of updates processed concurrently, defaults to DefaultMaxRoutines.
+	// Set it to 1 to process the updates one by one.
+	// With ChatOrdering, the updates waiting for the previous update of their chat count too.
+	MaxRoutines int
+	// ChatOrdering processes the updates of the same chat sequentially in the order they were received,
+	// while the updates of different chats are still processed concurrently.
+	ChatOrdering bool
+	// Logger logs the errors of the handlers if no ErrorHandler is set, defaults to gottbot.DefaultLogger.
+	Logger gottbot.Logger
 }
 
 // NewDispatcher creates a new general dispatcher.
 func NewDispatcher(errorHandler func(*gottbot.Bot, *gottbot.Update, error)) *GeneralDispatcher {
+	return NewDispatcherWithOpts(&DispatcherOpts{
+		ErrorHandler: errorHandler,
+	})
+}
+
+// NewDispatcherWithOpts creates a new general dispatcher with the provided options.
+func NewDispatcherWithOpts(opts *DispatcherOpts) *GeneralDispatcher {
+	if opts == nil {
+		opts = new(DispatcherOpts)
+	}
+	if opts.MaxRoutines <= 0 {
+		opts.MaxRoutines = DefaultMaxRoutines
+	}
+	if opts.Logger == nil {
+		opts.Logger = gottbot.DefaultLogger
+	}
 	return &GeneralDispatcher{
 		handlerGroups: make([]int, 0),
 		handlerMap:    make(map[int][]Handler),
-		ErrorHandler:  errorHandler,
+		disabled:      make(map[HandlerID]struct{}),
+		ErrorHandler:  opts.ErrorHandler,
+		logger:        opts.Logger,
+		limiter:       make(chan struct{}, opts.MaxRoutines),
+		chatOrdering:  opts.ChatOrdering,
 	}
 }
 
-// func (g *GeneralDispatcher) setUpdateChan(update chan gottbot.Update) {
-// 	g.update = update
-// }
-
+// Run processes the updates received from updateChan concurrently until the channel is closed,
+// it returns once all the received updates have been processed.
 func (g *GeneralDispatcher) Run(bot *gottbot.Bot, updateChan chan *gottbot.Update) {
+	var wg sync.WaitGroup
+	queues := &chatQueues{pending: make(map[int64][]*gottbot.Update)}
 	for update := range updateChan {
+		// every update holds a slot from here until it is processed, queued ones included,
+		// so a burst blocks the sender instead of piling up
+		g.limiter <- struct{}{}
+		if g.chatOrdering {
+			if chatId := NewContext(update).EffectiveChatId; chatId != 0 {
+				if queues.push(chatId, update) {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						g.processChat(bot, queues, chatId)
+					}()
+				}
+				continue
+			}
+		}
+		wg.Add(1)
+		go func(update *gottbot.Update) {
+			defer func() {
+				<-g.limiter
+				wg.Done()
+			}()
+			g.processUpdate(bot, update)
+		}(update)
+	}
+	wg.Wait()
+}
+
+// processChat processes the queued updates of a chat one by one until its queue is empty.
+func (g *GeneralDispatcher) processChat(bot *gottbot.Bot, queues *chatQueues, chatId int64) {
+	for {
+		update := queues.pop(chatId)
+		if update == nil {
+			return
+		}
+		// the slot of the update was taken when it was queued
 		g.processUpdate(bot, update)
+		<-g.limiter
 	}
 }
 
+// chatQueues holds the updates waiting for the previous update of the same chat.
+type chatQueues struct {
+	mu      sync.Mutex
+	pending map[int64][]*gottbot.Update
+}
+
+// push queues the update and reports whether the chat had no queue,
+// in which case the caller should start processing the chat.
+func (q *chatQueues) push(chatId int64, update *gottbot.Update) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	pending, active := q.pending[chatId]
+	q.pending[chatId] = append(pending, update)
+	return !active
+}
+
+// pop returns the next update of the chat, or nil after removing the empty queue.
+func (q *chatQueues) pop(chatId int64) *gottbot.Update {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	pending := q.pending[chatId]
+	if len(pending) == 0 {
+		delete(q.pending, chatId)
+		return nil
+	}
+	q.pending[chatId] = pending[1:]
+	return pending[0]
+}
+
+// PanicError is passed to the ErrorHandler when a handler panics while processing an update.
+type PanicError struct {
+	// Value recovered from the panic
+	Value any
+	// Stack trace of the goroutine at the time of the panic
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic while processing the update: %v\n%s", e.Value, e.Stack)
+}
+
+// processUpdate runs the handlers of the update group by group, in ascending order of the groups.
+// A panic in a handler stops the processing of the update and is reported as a *PanicError.
 func (g *GeneralDispatcher) processUpdate(bot *gottbot.Bot, update *gottbot.Update) {
-	for _, handlers := range g.handlerMap {
+	var ctx *Context
+	defer func() {
+		if r := recover(); r != nil {
+			g.handleError(bot, update, ctx, &PanicError{Value: r, Stack: debug.Stack()})
+		}
+	}()
+	g.mu.RLock()
+	groups := make([][]Handler, len(g.handlerGroups))
+	for i, group := range g.handlerGroups {
+		groups[i] = g.handlerMap[group]
+		if len(g.disabled) == 0 {
+			continue
+		}
+		enabled := make([]Handler, 0, len(groups[i]))
+		for _, handler := range groups[i] {
+			if _, ok := g.disabled[handler.GetHandlerID()]; !ok {
+				enabled = append(enabled, handler)
+			}
+		}
+		groups[i] = enabled
+	}
+	middlewares := g.middlewares
+	g.mu.RUnlock()
+	for _, handlers := range groups {
 		for _, handler := range handlers {
 			if !handler.CheckUpdate(update) {
 				continue
 			}
-			ctx := NewContext(update)
-			err := handler.HandleUpdate(bot, ctx)
+			if ctx == nil {
+				ctx = NewContext(update)
+			}
+			err := chainMiddlewares(middlewares, handler.HandleUpdate)(bot, ctx)
 			if err == nil || errors.Is(err, SkipCurrentGroup) {
 				break
 			}
@@ -64,26 +237,83 @@ func (g *GeneralDispatcher) processUpdate(bot *gottbot.Bot, update *gottbot.Upda
 				return
 			case errors.Is(err, ContinueGroup):
 				continue
-			case g.ErrorHandler != nil:
-				g.ErrorHandler(bot, update, err)
 			default:
-				fmt.Println("An error occured:", err.Error())
+				g.handleError(bot, update, ctx, err)
 			}
 		}
 	}
 }
 
+// handleError passes the error to the ErrorHandler or logs it, ctx is nil if not built yet.
+func (g *GeneralDispatcher) handleError(bot *gottbot.Bot, update *gottbot.Update, ctx *Context, err error) {
+	if g.ErrorHandler != nil {
+		g.ErrorHandler(bot, update, err)
+		return
+	}
+	args := []any{"update_type", update.GetUpdateType(), "error", err}
+	if ctx != nil {
+		args = append(args, "chat_id", ctx.EffectiveChatId)
+	}
+	g.logger.Error("failed to process update", args...)
+}
+
+// Use appends the middlewares to the chain wrapping every handler matching an update.
+// Middlewares run in the order they were added, the first one being the outermost.
+func (g *GeneralDispatcher) Use(middlewares ...Middleware) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	// copied on write since processUpdate may be iterating the old slice
+	newMiddlewares := make([]Middleware, 0, len(g.middlewares)+len(middlewares))
+	newMiddlewares = append(newMiddlewares, g.middlewares...)
+	g.middlewares = append(newMiddlewares, middlewares...)
+}
+
 // AddHandlerToGroup appends the provided handler to the provided handler group.
+// Groups are executed in ascending order.
+//
+// The handler is not added if its HandlerID is already used by another handler
+// or if it is already in the group, the error is logged instead (see TryAddHandlerToGroup).
 func (g *GeneralDispatcher) AddHandlerToGroup(group int, handler Handler) HandlerID {
+	id, err := g.TryAddHandlerToGroup(group, handler)
+	if err != nil {
+		g.logger.Error("failed to add handler", "handler_id", id, "group", group, "error", err)
+	}
+	return id
+}
+
+// TryAddHandlerToGroup is like AddHandlerToGroup but returns DuplicateHandlerIDError
+// instead of logging it if the handler can't be added.
+// The same handler may still be added to several groups.
+func (g *GeneralDispatcher) TryAddHandlerToGroup(group int, handler Handler) (HandlerID, error) {
+	id := handler.GetHandlerID()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, registeredGroup := range g.handlerGroups {
+		for _, registered := range g.handlerMap[registeredGroup] {
+			if registered.GetHandlerID() == id && (registeredGroup == group || !sameHandler(registered, handler)) {
+				return id, fmt.Errorf("failed to add handler %q to group %d: %w", id, group, DuplicateHandlerIDError)
+			}
+		}
+	}
 	handlers, ok := g.handlerMap[group]
 	if !ok {
 		handlers = make([]Handler, 0)
-		g.handlerGroups = append(g.handlerGroups, group)
-		sort.Ints(g.handlerGroups)
+		i := sort.SearchInts(g.handlerGroups, group)
+		g.handlerGroups = append(g.handlerGroups, 0)
+		copy(g.handlerGroups[i+1:], g.handlerGroups[i:])
+		g.handlerGroups[i] = group
 	}
-	handlers = append(handlers, handler)
-	g.handlerMap[group] = handlers
-	return handler.GetHandlerID()
+	// the handlers are copied on write since processUpdate may be iterating the old slice
+	newHandlers := make([]Handler, len(handlers), len(handlers)+1)
+	copy(newHandlers, handlers)
+	g.handlerMap[group] = append(newHandlers, handler)
+	return id, nil
+}
+
+// sameHandler reports whether a and b are the same handler, handlers of non-comparable types never are.
+func sameHandler(a, b Handler) bool {
+	ta, tb := reflect.TypeOf(a), reflect.TypeOf(b)
+	return ta == tb && ta.Comparable() && a == b
 }
 
 // AddHandler appends the provided handler to the handler group 0.
@@ -93,22 +323,144 @@ func (g *GeneralDispatcher) AddHandler(handler Handler) HandlerID {
 
 // RemoveGroup removes the whole handler group.
 func (g *GeneralDispatcher) RemoveGroup(group int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.removeGroup(group)
+}
+
+// removeGroup removes the group from both handlerMap and handlerGroups, mu must be held.
+func (g *GeneralDispatcher) removeGroup(group int) {
 	delete(g.handlerMap, group)
+	i := sort.SearchInts(g.handlerGroups, group)
+	if i < len(g.handlerGroups) && g.handlerGroups[i] == group {
+		g.handlerGroups = append(g.handlerGroups[:i:i], g.handlerGroups[i+1:]...)
+	}
 }
 
-// RemoveHandler removes the handler from any further service.
+// RemoveHandler removes the handler from any further service, from all the groups it was added to.
+// The order of the remaining handlers of its groups is preserved.
 func (g *GeneralDispatcher) RemoveHandler(id HandlerID) bool {
-	for group, handlers := range g.handlerMap {
-		for i, handler := range handlers {
-			if handler.GetHandlerID() != id {
-				continue
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	removed := false
+	for {
+		group, i, ok := g.findHandler(id)
+		if !ok {
+			break
+		}
+		g.removeHandlerAt(group, i)
+		removed = true
+	}
+	if removed {
+		delete(g.disabled, id)
+	}
+	return removed
+}
+
+// removeHandlerAt removes the i-th handler of the group, mu must be held.
+func (g *GeneralDispatcher) removeHandlerAt(group, i int) {
+	handlers := g.handlerMap[group]
+	if len(handlers) == 1 {
+		g.removeGroup(group)
+		return
+	}
+	// the handlers are copied on write since processUpdate may be iterating the old slice
+	newHandlers := make([]Handler, 0, len(handlers)-1)
+	newHandlers = append(newHandlers, handlers[:i]...)
+	g.handlerMap[group] = append(newHandlers, handlers[i+1:]...)
+}
+
+// findHandler returns the group and the index of the handler with the provided id, mu must be held.
+func (g *GeneralDispatcher) findHandler(id HandlerID) (int, int, bool) {
+	for _, group := range g.handlerGroups {
+		for i, handler := range g.handlerMap[group] {
+			if handler.GetHandlerID() == id {
+				return group, i, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// GetUpdateTypes returns the types of the updates handled by the registered handlers,
+// or nil if a handler doesn't implement UpdateTypesHandler and so may handle any update.
+func (g *GeneralDispatcher) GetUpdateTypes() []gottbot.UpdateType {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	seen := make(map[gottbot.UpdateType]struct{})
+	updateTypes := make([]gottbot.UpdateType, 0)
+	for _, group := range g.handlerGroups {
+		for _, handler := range g.handlerMap[group] {
+			h, ok := handler.(UpdateTypesHandler)
+			if !ok {
+				return nil
+			}
+			for _, updateType := range h.GetUpdateTypes() {
+				if _, ok := seen[updateType]; !ok {
+					seen[updateType] = struct{}{}
+					updateTypes = append(updateTypes, updateType)
+				}
 			}
-			handlers[i] = handlers[len(handlers)-1]
-			handlers[len(handlers)-1] = nil
-			handlers = handlers[:len(handlers)-1]
-			g.handlerMap[group] = handlers
-			return true
 		}
 	}
-	return false
+	return updateTypes
+}
+
+// ListHandlers returns all the registered handlers in the order they are executed in.
+func (g *GeneralDispatcher) ListHandlers() []HandlerInfo {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	infos := make([]HandlerInfo, 0)
+	for _, group := range g.handlerGroups {
+		for _, handler := range g.handlerMap[group] {
+			infos = append(infos, g.handlerInfo(group, handler))
+		}
+	}
+	return infos
+}
+
+// LookupHandler returns the handler registered with the provided id, in the first of its groups.
+func (g *GeneralDispatcher) LookupHandler(id HandlerID) (HandlerInfo, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	group, i, ok := g.findHandler(id)
+	if !ok {
+		return HandlerInfo{}, false
+	}
+	return g.handlerInfo(group, g.handlerMap[group][i]), true
+}
+
+func (g *GeneralDispatcher) handlerInfo(group int, handler Handler) HandlerInfo {
+	id := handler.GetHandlerID()
+	_, disabled := g.disabled[id]
+	return HandlerInfo{
+		ID:      id,
+		Group:   group,
+		Handler: handler,
+		Enabled: !disabled,
+	}
+}
+
+// EnableHandler enables the handler disabled by DisableHandler.
+// It returns false if no handler is registered with the provided id.
+func (g *GeneralDispatcher) EnableHandler(id HandlerID) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, _, ok := g.findHandler(id); !ok {
+		return false
+	}
+	delete(g.disabled, id)
+	return true
+}
+
+// DisableHandler stops the handler from receiving updates without removing it from its group.
+// It returns false if no handler is registered with the provided id.
+func (g *GeneralDispatcher) DisableHandler(id HandlerID) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, _, ok := g.findHandler(id); !ok {
+		return false
+	}
+	g.disabled[id] = struct{}{}
+	return true
 }
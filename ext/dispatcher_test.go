@@ -0,0 +1,113 @@
+package ext
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/anonyindian/gottbot"
+)
+
+// blockingHandler handles every update once it is released.
+type blockingHandler struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (h *blockingHandler) CheckUpdate(*gottbot.Update) bool { return true }
+
+func (h *blockingHandler) HandleUpdate(*gottbot.Bot, *Context) error {
+	h.started <- struct{}{}
+	<-h.release
+	return nil
+}
+
+func (h *blockingHandler) GetHandlerID() HandlerID { return "blocking" }
+
+func TestChatOrderingIsBounded(t *testing.T) {
+	handler := &blockingHandler{started: make(chan struct{}, 10), release: make(chan struct{})}
+	dispatcher := NewDispatcherWithOpts(&DispatcherOpts{MaxRoutines: 2, ChatOrdering: true, Logger: gottbot.NopLogger})
+	dispatcher.AddHandler(handler)
+	updateChan := make(chan *gottbot.Update)
+	done := make(chan struct{})
+	go func() {
+		dispatcher.Run(nil, updateChan)
+		close(done)
+	}()
+
+	chatUpdate := func() *gottbot.Update {
+		return &gottbot.Update{
+			Type:       gottbot.UpdateTypeBotStarted,
+			BotStarted: &gottbot.BotStarted{ChatId: 1},
+		}
+	}
+	// the first update is processed and the second one waits for it, both take a slot
+	for i := 0; i < 2; i++ {
+		select {
+		case updateChan <- chatUpdate():
+		case <-time.After(time.Second):
+			t.Fatalf("update %d was not accepted", i)
+		}
+	}
+	<-handler.started
+	// Run receives the third update and then waits for a slot, so the fourth one can't be sent
+	select {
+	case updateChan <- chatUpdate():
+	case <-time.After(time.Second):
+		t.Fatal("the third update was not received")
+	}
+	select {
+	case updateChan <- chatUpdate():
+		t.Fatal("the dispatcher accepted more updates than MaxRoutines")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(handler.release)
+	close(updateChan)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the dispatcher didn't return")
+	}
+}
+
+// namedHandler never handles any update.
+type namedHandler struct {
+	id HandlerID
+}
+
+func (h *namedHandler) CheckUpdate(*gottbot.Update) bool { return false }
+
+func (h *namedHandler) HandleUpdate(*gottbot.Bot, *Context) error { return nil }
+
+func (h *namedHandler) GetHandlerID() HandlerID { return h.id }
+
+func TestDuplicateHandlerID(t *testing.T) {
+	dispatcher := NewDispatcherWithOpts(&DispatcherOpts{Logger: gottbot.NopLogger})
+	first, second := &namedHandler{id: "name"}, &namedHandler{id: "name"}
+	if _, err := dispatcher.TryAddHandlerToGroup(0, first); err != nil {
+		t.Fatalf("TryAddHandlerToGroup(0, first) error = %v", err)
+	}
+	if _, err := dispatcher.TryAddHandlerToGroup(1, second); !errors.Is(err, DuplicateHandlerIDError) {
+		t.Errorf("TryAddHandlerToGroup(1, second) error = %v, want DuplicateHandlerIDError", err)
+	}
+	if _, err := dispatcher.TryAddHandlerToGroup(0, first); !errors.Is(err, DuplicateHandlerIDError) {
+		t.Errorf("TryAddHandlerToGroup(0, first) error = %v, want DuplicateHandlerIDError", err)
+	}
+	// the same handler can still be added to another group
+	if _, err := dispatcher.TryAddHandlerToGroup(1, first); err != nil {
+		t.Errorf("TryAddHandlerToGroup(1, first) error = %v", err)
+	}
+	// AddHandler logs the error and keeps the registered handler
+	dispatcher.AddHandler(second)
+	infos := dispatcher.ListHandlers()
+	if len(infos) != 2 || infos[0].Handler != first || infos[0].Group != 0 || infos[1].Handler != first || infos[1].Group != 1 {
+		t.Fatalf("ListHandlers() = %+v, want first in groups 0 and 1", infos)
+	}
+	if !dispatcher.RemoveHandler("name") {
+		t.Fatal("RemoveHandler() = false, want true")
+	}
+	if infos := dispatcher.ListHandlers(); len(infos) != 0 {
+		t.Errorf("ListHandlers() after RemoveHandler = %+v, want none", infos)
+	}
+}
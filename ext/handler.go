@@ -9,3 +9,10 @@ type Handler interface {
 	CheckUpdate(update *gottbot.Update) bool
 	GetHandlerID() HandlerID
 }
+
+// UpdateTypesHandler is implemented by the handlers which only handle some types of updates,
+// it lets the updater subscribe to the updates needed by the dispatcher only.
+type UpdateTypesHandler interface {
+	Handler
+	GetUpdateTypes() []gottbot.UpdateType
+}
@@ -0,0 +1,20 @@
+package ext
+
+import "github.com/anonyindian/gottbot"
+
+// HandlerFunc has the signature of Handler.HandleUpdate.
+type HandlerFunc func(bot *gottbot.Bot, ctx *Context) error
+
+// Middleware wraps the HandleUpdate of every handler matching an update.
+//
+// A middleware can run code before and after calling next, enrich ctx.Data for the handler,
+// or short-circuit by returning without calling next, e.g. with EndGroups to stop processing the update.
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// chainMiddlewares wraps the handler with the middlewares, the first middleware being the outermost.
+func chainMiddlewares(middlewares []Middleware, handler HandlerFunc) HandlerFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
@@ -1,9 +1,13 @@
 package ext
 
 import (
-	"encoding/json"
-	"fmt"
+	"context"
+	"errors"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/anonyindian/gottbot"
@@ -11,16 +15,60 @@ import (
 
 // Updater fetches the updates from bot api
 //
-// Either by StartWebhook or by StartPolling
+// Either by StartWebhook or by StartPolling, for as many bots as needed.
+// Bots can be added and removed while the updater is running.
 type Updater struct {
+	// Dispatcher handles the updates of the bots added without their own dispatcher.
 	Dispatcher Dispatcher
-	update     chan *gottbot.Update
+
+	// ctx is cancelled as soon as Stop is called, it aborts the pending fetches and deliveries.
+	ctx    context.Context
+	cancel context.CancelFunc
+	// mu guards the fields below.
+	mu     sync.RWMutex
+	closed bool
+	logger gottbot.Logger
+	bots   map[*gottbot.Bot]*updaterBot
+	// servers are the webhook servers by their address.
+	servers map[string]*webhookServer
+	// subscriptions made by StartWebhook and SubscribeWebhook
+	subscriptions []webhookSubscription
+	dispatching   sync.WaitGroup
+	stopOnce      sync.Once
+}
+
+// updaterBot is the state of a bot added to the updater.
+type updaterBot struct {
+	// updates delivers the updates of the bot to its dispatcher.
+	updates    chan *gottbot.Update
+	dispatcher Dispatcher
+	// senders counts the pending deliveries, updates is closed once they have returned.
+	senders sync.WaitGroup
+	// ctx is cancelled when the bot is removed or the updater is stopped.
+	ctx     context.Context
+	cancel  context.CancelFunc
+	polling bool
+	// webhooks are the addresses and paths the bot is served on.
+	webhooks []webhookRoute
+	// done is closed once the dispatcher has processed all the updates of the bot.
+	done chan struct{}
 }
 
+var (
+	UpdaterStoppedError  = errors.New("the updater is stopped")
+	BotAlreadyAddedError = errors.New("the bot is already added to the updater")
+	BotNotAddedError     = errors.New("the bot is not added to the updater")
+)
+
 // Optional fields for the NewUpdater
 type UpdaterOpts struct {
 	Dispatcher   Dispatcher
 	ErrorHandler func(*gottbot.Bot, *gottbot.Update, error)
+	// DispatcherOpts are used to create the GeneralDispatcher if no Dispatcher is provided.
+	DispatcherOpts *DispatcherOpts
+	// Logger logs the fetching errors and dropped updates, defaults to gottbot.DefaultLogger.
+	// It is also used by the created GeneralDispatcher unless DispatcherOpts has its own.
+	Logger gottbot.Logger
 }
 
 // NewUpdater creates a new Updater.
@@ -28,74 +76,291 @@ func NewUpdater(opts *UpdaterOpts) *Updater {
 	if opts == nil {
 		opts = new(UpdaterOpts)
 	}
-	updateChan := make(chan *gottbot.Update)
+	if opts.Logger == nil {
+		opts.Logger = gottbot.DefaultLogger
+	}
 	if opts.Dispatcher == nil {
-		opts.Dispatcher = NewDispatcher(opts.ErrorHandler)
+		dispatcherOpts := DispatcherOpts{}
+		if opts.DispatcherOpts != nil {
+			dispatcherOpts = *opts.DispatcherOpts
+		}
+		if dispatcherOpts.ErrorHandler == nil {
+			dispatcherOpts.ErrorHandler = opts.ErrorHandler
+		}
+		if dispatcherOpts.Logger == nil {
+			dispatcherOpts.Logger = opts.Logger
+		}
+		opts.Dispatcher = NewDispatcherWithOpts(&dispatcherOpts)
 	}
+	ctx, cancel := context.WithCancel(context.Background())
 	return &Updater{
 		Dispatcher: opts.Dispatcher,
-		update:     updateChan,
+		ctx:        ctx,
+		cancel:     cancel,
+		logger:     opts.Logger,
+		bots:       make(map[*gottbot.Bot]*updaterBot),
+		servers:    make(map[string]*webhookServer),
+	}
+}
+
+// AddBot adds the bot to the updater, its updates are handled by the provided dispatcher
+// or by the Dispatcher of the updater if nil.
+// Calling it is only needed to use a dispatcher of its own, StartPolling and StartWebhook add the bot otherwise.
+func (u *Updater) AddBot(bot *gottbot.Bot, dispatcher Dispatcher) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.closed {
+		return UpdaterStoppedError
+	}
+	if _, ok := u.bots[bot]; ok {
+		return BotAlreadyAddedError
+	}
+	u.addBot(bot, dispatcher)
+	return nil
+}
+
+// addBot adds the bot and runs its dispatcher, mu must be held for writing.
+func (u *Updater) addBot(bot *gottbot.Bot, dispatcher Dispatcher) *updaterBot {
+	if dispatcher == nil {
+		dispatcher = u.Dispatcher
+	}
+	ctx, cancel := context.WithCancel(u.ctx)
+	b := &updaterBot{
+		updates:    make(chan *gottbot.Update),
+		dispatcher: dispatcher,
+		ctx:        ctx,
+		cancel:     cancel,
+		done:       make(chan struct{}),
+	}
+	u.bots[bot] = b
+	u.dispatching.Add(1)
+	go func() {
+		defer func() {
+			close(b.done)
+			u.dispatching.Done()
+		}()
+		dispatcher.Run(bot, b.updates)
+	}()
+	return b
+}
+
+// getOrAddBot returns the state of the bot, adding it with the shared dispatcher if needed.
+// mu must be held for writing.
+func (u *Updater) getOrAddBot(bot *gottbot.Bot) *updaterBot {
+	if b, ok := u.bots[bot]; ok {
+		return b
+	}
+	return u.addBot(bot, nil)
+}
+
+// Bots returns the bots added to the updater.
+func (u *Updater) Bots() []*gottbot.Bot {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	bots := make([]*gottbot.Bot, 0, len(u.bots))
+	for bot := range u.bots {
+		bots = append(bots, bot)
+	}
+	return bots
+}
+
+// RemoveBot stops fetching the updates of the bot, removes its webhook subscriptions and paths
+// and waits for its dispatcher to finish the updates being processed.
+// The webhook servers left without a bot are shut down.
+// If ctx expires before that, RemoveBot returns the error of the context.
+func (u *Updater) RemoveBot(ctx context.Context, bot *gottbot.Bot) error {
+	u.mu.RLock()
+	b, ok := u.bots[bot]
+	u.mu.RUnlock()
+	if !ok {
+		return BotNotAddedError
+	}
+	u.mu.Lock()
+	if u.bots[bot] != b {
+		u.mu.Unlock()
+		return BotNotAddedError
+	}
+	delete(u.bots, bot)
+	var subscriptions []webhookSubscription
+	kept := make([]webhookSubscription, 0, len(u.subscriptions))
+	for _, subscription := range u.subscriptions {
+		if subscription.bot == bot {
+			subscriptions = append(subscriptions, subscription)
+		} else {
+			kept = append(kept, subscription)
+		}
+	}
+	u.subscriptions = kept
+	var servers []*http.Server
+	for _, route := range b.webhooks {
+		if route.server.remove(route.path) == 0 && u.servers[route.addr] == route.server {
+			delete(u.servers, route.addr)
+			servers = append(servers, route.server.server)
+		}
+	}
+	u.mu.Unlock()
+	b.close()
+
+	var err error
+	for _, subscription := range subscriptions {
+		if _, unsubscribeErr := bot.UnsubscribeCtx(ctx, subscription.url); unsubscribeErr != nil {
+			u.logger.Error("failed to remove webhook subscription", "url", subscription.url, "error", unsubscribeErr)
+		}
+	}
+	for _, server := range servers {
+		if shutdownErr := server.Shutdown(ctx); shutdownErr != nil && err == nil {
+			err = shutdownErr
+		}
+	}
+	select {
+	case <-b.done:
+	case <-ctx.Done():
+		err = ctx.Err()
 	}
+	return err
 }
 
+// close cancels the pending deliveries and closes the update channel once they have returned,
+// the bot must have been removed from the updater so that no new delivery can start.
+func (b *updaterBot) close() {
+	b.cancel()
+	b.senders.Wait()
+	close(b.updates)
+}
+
+// send delivers the update of the bot to its dispatcher, it returns false if the bot
+// was removed or the updater is stopping.
+// The lock is not held while delivering, so a slow dispatcher can't block the other bots.
+func (u *Updater) send(bot *gottbot.Bot, update *gottbot.Update) bool {
+	u.mu.RLock()
+	b, ok := u.bots[bot]
+	if u.closed || !ok {
+		u.mu.RUnlock()
+		return false
+	}
+	b.senders.Add(1)
+	u.mu.RUnlock()
+	defer b.senders.Done()
+	select {
+	case b.updates <- update:
+		return true
+	case <-b.ctx.Done():
+		return false
+	}
+}
+
+// StartPolling starts fetching the updates of the bot with long polling.
+// The long polling timeout defaults to gottbot.POLL_TIMEOUT if not set in opts.
 func (u *Updater) StartPolling(bot *gottbot.Bot, opts *gottbot.GetUpdatesOpts) {
 	if opts == nil {
 		opts = new(gottbot.GetUpdatesOpts)
 	}
-	go u.Dispatcher.Run(bot, u.update)
+	if opts.Timeout == 0 {
+		opts.Timeout = gottbot.POLL_TIMEOUT
+	}
+	u.mu.Lock()
+	if u.closed {
+		u.mu.Unlock()
+		return
+	}
+	b := u.getOrAddBot(bot)
+	if b.polling {
+		u.mu.Unlock()
+		u.logger.Warn("the bot is already polling")
+		return
+	}
+	b.polling = true
+	u.mu.Unlock()
 	go func() {
-		for {
-			updates, err := bot.GetUpdates(opts)
+		failures := 0
+		for b.ctx.Err() == nil {
+			updates, err := bot.GetUpdatesCtx(b.ctx, opts)
 			if err != nil {
-				fmt.Println("An error occured while fetching updates:", err.Error())
+				if b.ctx.Err() != nil {
+					return
+				}
+				failures++
+				delay := bot.GetRetryPolicy().Backoff(failures)
+				u.logger.Error("failed to fetch updates", "error", err, "failures", failures, "retry_in", delay)
+				timer := time.NewTimer(delay)
+				select {
+				case <-b.ctx.Done():
+					timer.Stop()
+					return
+				case <-timer.C:
+				}
 				continue
 			}
+			failures = 0
 			opts.Marker = updates.Marker
-			for _, update := range updates.Updates {
-				u.update <- &update
+			// send the address of the element, not of a loop variable shared by the iterations
+			for i := range updates.Updates {
+				update := &updates.Updates[i]
+				if !u.send(bot, update) {
+					u.logger.Warn("dropped update, the bot was removed or the updater is stopping", "update_type", update.GetUpdateType())
+					return
+				}
 			}
 		}
 	}()
 }
 
-type WebhookOpts struct {
-	Domain      string
-	Port        int
-	Path        string
-	ReadTimeout time.Duration
-}
+// Stop stops fetching the updates, removes the webhook subscriptions made by the updater,
+// shuts down the webhook servers and waits for the dispatchers to finish the updates being processed.
+// If ctx expires before that, Stop returns the error of the context.
+func (u *Updater) Stop(ctx context.Context) error {
+	var err error
+	u.stopOnce.Do(func() {
+		// closing first keeps the webhooks and bots from being added while stopping
+		u.mu.Lock()
+		u.closed = true
+		subscriptions := u.subscriptions
+		servers := make([]*http.Server, 0, len(u.servers))
+		for _, server := range u.servers {
+			servers = append(servers, server.server)
+		}
+		bots := u.bots
+		u.bots = make(map[*gottbot.Bot]*updaterBot)
+		u.mu.Unlock()
+		u.cancel()
 
-func (u *Updater) StartWebhook(bot *gottbot.Bot, opts *WebhookOpts) {
-	if opts == nil {
-		opts = new(WebhookOpts)
-	}
-	if opts.Domain == "" {
-		opts.Domain = "0.0.0.0"
-	}
-	if opts.Port == 0 {
-		opts.Port = 8080
-	}
-	go u.Dispatcher.Run(bot, u.update)
-	mux := http.NewServeMux()
-	mux.HandleFunc("/"+opts.Path, func(w http.ResponseWriter, r *http.Request) {
-		var update gottbot.Update
-		_ = json.NewDecoder(r.Body).Decode(&update)
-		u.update <- &update
-	})
-	server := http.Server{
-		Addr:        fmt.Sprintf("%s:%d", opts.Domain, opts.Port),
-		Handler:     mux,
-		ReadTimeout: opts.ReadTimeout,
-	}
-	go func() {
-		if err := server.ListenAndServe(); err != nil {
-			panic("failed to create webhook server: " + err.Error())
+		for _, subscription := range subscriptions {
+			if _, unsubscribeErr := subscription.bot.UnsubscribeCtx(ctx, subscription.url); unsubscribeErr != nil {
+				u.logger.Error("failed to remove webhook subscription", "url", subscription.url, "error", unsubscribeErr)
+			}
 		}
-	}()
+		for _, server := range servers {
+			if shutdownErr := server.Shutdown(ctx); shutdownErr != nil && err == nil {
+				err = shutdownErr
+			}
+		}
+		for _, b := range bots {
+			b.close()
+		}
+
+		done := make(chan struct{})
+		go func() {
+			u.dispatching.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+	})
+	return err
 }
 
-func (u *Updater) Idle() {
-	for {
-		time.Sleep(time.Second * 1)
-	}
+// Idle blocks until the process receives SIGINT or SIGTERM and then stops the updater.
+func (u *Updater) Idle() error {
+	return u.IdleCtx(context.Background())
+}
+
+// IdleCtx is the context-aware version of Idle, it also returns once ctx is cancelled.
+func (u *Updater) IdleCtx(ctx context.Context) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
+	return u.Stop(context.Background())
 }
@@ -0,0 +1,261 @@
+package ext
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anonyindian/gottbot"
+)
+
+// recordingDispatcher records the received updates in order instead of handling them.
+type recordingDispatcher struct {
+	*GeneralDispatcher
+	updates chan *gottbot.Update
+}
+
+func (d *recordingDispatcher) Run(_ *gottbot.Bot, updateChan chan *gottbot.Update) {
+	for update := range updateChan {
+		d.updates <- update
+	}
+}
+
+func TestStartPollingDeliversBatchInOrder(t *testing.T) {
+	mids := []string{"mid.1", "mid.2", "mid.3"}
+	markers := make(chan string, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		markers <- r.URL.Query().Get("marker")
+		if r.URL.Query().Get("marker") != "" {
+			// the next batch is empty, wait like a long polling request would
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			fmt.Fprint(w, `{"updates":[],"marker":42}`)
+			return
+		}
+		fmt.Fprint(w, `{"updates":[`)
+		for i, mid := range mids {
+			if i > 0 {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintf(w, `{"update_type":"message_created","timestamp":%d,"message":{"recipient":{"chat_id":1},"body":{"mid":%q,"seq":%d},"timestamp":%d}}`, i+1, mid, i+1, i+1)
+		}
+		fmt.Fprint(w, `],"marker":42}`)
+	}))
+	defer server.Close()
+
+	bot, err := gottbot.NewBot("token", &gottbot.BotOpts{APIURL: server.URL, DisableTokenVerification: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dispatcher := &recordingDispatcher{GeneralDispatcher: NewDispatcher(nil), updates: make(chan *gottbot.Update, len(mids))}
+	updater := NewUpdater(&UpdaterOpts{Dispatcher: dispatcher, Logger: gottbot.NopLogger})
+	updater.StartPolling(bot, nil)
+	defer updater.Stop(context.Background())
+
+	received := make([]*gottbot.Update, 0, len(mids))
+	for range mids {
+		select {
+		case update := <-dispatcher.updates:
+			received = append(received, update)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("received %d updates, want %d", len(received), len(mids))
+		}
+	}
+	for i, update := range received {
+		for _, other := range received[:i] {
+			if update == other {
+				t.Fatalf("update %d shares its pointer with a previous update", i)
+			}
+		}
+		if update.MessageCreated == nil || update.MessageCreated.Message == nil {
+			t.Fatalf("update %d has no message: %+v", i, update)
+		}
+		if got := update.MessageCreated.Message.Body.Mid; got != mids[i] {
+			t.Errorf("update %d has mid %q, want %q", i, got, mids[i])
+		}
+		if got := update.MessageCreated.Timestamp; got != int64(i+1) {
+			t.Errorf("update %d has timestamp %d, want %d", i, got, i+1)
+		}
+	}
+
+	if marker := <-markers; marker != "" {
+		t.Errorf("first request sent marker %q, want none", marker)
+	}
+	select {
+	case marker := <-markers:
+		if marker != "42" {
+			t.Errorf("second request sent marker %q, want 42", marker)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no second GetUpdates request")
+	}
+}
+
+// blockedDispatcher doesn't read the updates until it is released.
+type blockedDispatcher struct {
+	*GeneralDispatcher
+	release chan struct{}
+}
+
+func (d *blockedDispatcher) Run(_ *gottbot.Bot, updateChan chan *gottbot.Update) {
+	<-d.release
+	for range updateChan {
+	}
+}
+
+func postUpdate(handler http.Handler) int {
+	body := `{"update_type":"bot_started","timestamp":1,"chat_id":1,"user":{"user_id":1}}`
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+	return recorder.Code
+}
+
+func TestBlockedBotDoesNotBlockOtherBots(t *testing.T) {
+	newBot := func() *gottbot.Bot {
+		bot, err := gottbot.NewBot("token", &gottbot.BotOpts{DisableTokenVerification: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return bot
+	}
+	blocked := &blockedDispatcher{GeneralDispatcher: NewDispatcher(nil), release: make(chan struct{})}
+	recording := &recordingDispatcher{GeneralDispatcher: NewDispatcher(nil), updates: make(chan *gottbot.Update, 1)}
+	updater := NewUpdater(&UpdaterOpts{Dispatcher: recording, Logger: gottbot.NopLogger})
+	botA, botB, botC := newBot(), newBot(), newBot()
+	if err := updater.AddBot(botA, blocked); err != nil {
+		t.Fatal(err)
+	}
+	handlerA := updater.WebhookHandler(botA, nil)
+	handlerB := updater.WebhookHandler(botB, nil)
+
+	go postUpdate(handlerA)
+	time.Sleep(50 * time.Millisecond)
+	added := make(chan error, 1)
+	go func() {
+		added <- updater.AddBot(botC, nil)
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	delivered := make(chan int, 1)
+	go func() {
+		delivered <- postUpdate(handlerB)
+	}()
+	select {
+	case code := <-delivered:
+		if code != http.StatusOK {
+			t.Errorf("delivery to bot B responded %d, want 200", code)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("delivery to bot B is blocked by bot A")
+	}
+	select {
+	case err := <-added:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("AddBot is blocked by bot A")
+	}
+
+	close(blocked.release)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := updater.Stop(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStartWebhookUndoesRegistrationOnSubscribeError(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"success":false,"message":"invalid url"}`)
+	}))
+	defer api.Close()
+	bot, err := gottbot.NewBot("token", &gottbot.BotOpts{APIURL: api.URL, DisableTokenVerification: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := freePort(t)
+
+	updater := NewUpdater(&UpdaterOpts{Logger: gottbot.NopLogger})
+	defer updater.Stop(context.Background())
+	opts := &WebhookOpts{Domain: "127.0.0.1", Port: port, URL: "https://example.com/webhook"}
+	for i := 0; i < 2; i++ {
+		err := updater.StartWebhook(bot, opts)
+		if err == nil || !strings.Contains(err.Error(), "invalid url") {
+			t.Fatalf("attempt %d: got error %v, want the subscription error", i, err)
+		}
+		if bots := updater.Bots(); len(bots) != 0 {
+			t.Fatalf("attempt %d: the bot is still added", i)
+		}
+		if isListening(port) {
+			t.Fatalf("attempt %d: the webhook server is still listening", i)
+		}
+	}
+}
+
+func freePort(t *testing.T) int {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+func isListening(port int) bool {
+	conn, err := net.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+func TestStartWebhookFailsWhileStopping(t *testing.T) {
+	unsubscribing := make(chan struct{}, 1)
+	release := make(chan struct{})
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			unsubscribing <- struct{}{}
+			<-release
+		}
+		fmt.Fprint(w, `{"success":true}`)
+	}))
+	defer api.Close()
+	bot, err := gottbot.NewBot("token", &gottbot.BotOpts{APIURL: api.URL, DisableTokenVerification: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	updater := NewUpdater(&UpdaterOpts{Logger: gottbot.NopLogger})
+	if err := updater.StartWebhook(bot, &WebhookOpts{Domain: "127.0.0.1", Port: freePort(t), URL: "https://example.com/a"}); err != nil {
+		t.Fatal(err)
+	}
+
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- updater.Stop(context.Background())
+	}()
+	<-unsubscribing
+	port := freePort(t)
+	if err := updater.StartWebhook(bot, &WebhookOpts{Domain: "127.0.0.1", Port: port, Path: "b"}); !errors.Is(err, UpdaterStoppedError) {
+		t.Errorf("StartWebhook while stopping returned %v, want UpdaterStoppedError", err)
+	}
+	if err := updater.AddBot(bot, nil); !errors.Is(err, UpdaterStoppedError) {
+		t.Errorf("AddBot while stopping returned %v, want UpdaterStoppedError", err)
+	}
+	close(release)
+	if err := <-stopped; err != nil {
+		t.Fatal(err)
+	}
+	if isListening(port) {
+		t.Error("a webhook server started while stopping is still listening")
+	}
+}
@@ -0,0 +1,379 @@
+package ext
+
+import (
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/anonyindian/gottbot"
+)
+
+// DefaultWebhookSecretHeader is the header checked for WebhookOpts.Secret by default.
+const DefaultWebhookSecretHeader = "X-Webhook-Secret"
+
+// DefaultWebhookMaxBodySize is the default limit of the size of the webhook requests.
+const DefaultWebhookMaxBodySize = 1 << 20
+
+type WebhookOpts struct {
+	Domain      string
+	Port        int
+	Path        string
+	ReadTimeout time.Duration
+	// CertFile and KeyFile enable TLS on the webhook server when both are set.
+	CertFile string
+	KeyFile  string
+	// Secret must be sent in the SecretHeader of every webhook request if set,
+	// the requests without it are rejected with 401 Unauthorized.
+	Secret string
+	// SecretHeader is the header carrying the Secret, defaults to DefaultWebhookSecretHeader.
+	SecretHeader string
+	// MaxBodySize limits the size of the webhook requests, defaults to DefaultWebhookMaxBodySize.
+	MaxBodySize int64
+	// URL is the public URL of the webhook. If set, StartWebhook subscribes the bot to it
+	// with SubscribeWebhook.
+	URL string
+	// UpdateTypes the subscription is made for, defaults to the types handled by the dispatcher of the bot.
+	UpdateTypes []gottbot.UpdateType
+	// DropStaleSubscriptions removes the other webhook subscriptions of the bot before subscribing.
+	DropStaleSubscriptions bool
+}
+
+// webhookSubscription is removed when the updater is stopped.
+type webhookSubscription struct {
+	bot *gottbot.Bot
+	url string
+}
+
+// webhookServer is a webhook server shared by the bots whose webhooks have the same address.
+type webhookServer struct {
+	server *http.Server
+	// opts the server was created with, the webhooks sharing it must use the same server options
+	opts WebhookOpts
+	// mu guards handlers, the bots can be added and removed while the server is running.
+	mu       sync.RWMutex
+	handlers map[string]http.Handler
+}
+
+// webhookRoute is a path the webhook of a bot is served on.
+type webhookRoute struct {
+	addr   string
+	path   string
+	server *webhookServer
+}
+
+func (s *webhookServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	handler, ok := s.handlers[r.URL.Path]
+	if !ok {
+		handler, ok = s.handlers["/"]
+	}
+	s.mu.RUnlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	handler.ServeHTTP(w, r)
+}
+
+// sameOptions reports whether the server has the server options of opts.
+func (s *webhookServer) sameOptions(opts *WebhookOpts) bool {
+	return s.opts.CertFile == opts.CertFile &&
+		s.opts.KeyFile == opts.KeyFile &&
+		s.opts.ReadTimeout == opts.ReadTimeout
+}
+
+// add serves the handler on path, it returns false if the path is taken.
+func (s *webhookServer) add(path string, handler http.Handler) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.handlers[path]; ok {
+		return false
+	}
+	s.handlers[path] = handler
+	return true
+}
+
+// remove stops serving path, it returns the number of paths still served.
+func (s *webhookServer) remove(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.handlers, path)
+	return len(s.handlers)
+}
+
+// StartWebhook starts a webhook server to receive the updates of the bot on opts.Path.
+//
+// Several bots can be served by the same server by starting their webhooks with the same
+// Domain and Port but different paths, their CertFile, KeyFile and ReadTimeout must be the same.
+// TLS is enabled when both CertFile and KeyFile are set, setting only one of them is an error.
+// It returns an error if the key pair can't be loaded, the server can't listen on the provided address,
+// the path is taken or the subscription to opts.URL fails, in which case the webhook is removed.
+func (u *Updater) StartWebhook(bot *gottbot.Bot, opts *WebhookOpts) error {
+	if opts == nil {
+		opts = new(WebhookOpts)
+	}
+	if opts.Domain == "" {
+		opts.Domain = "0.0.0.0"
+	}
+	if opts.Port == 0 {
+		opts.Port = 8080
+	}
+	if (opts.CertFile == "") != (opts.KeyFile == "") {
+		return errors.New("failed to create webhook server: both CertFile and KeyFile must be set to enable TLS")
+	}
+	route := webhookRoute{
+		addr: fmt.Sprintf("%s:%d", opts.Domain, opts.Port),
+		path: "/" + opts.Path,
+	}
+
+	u.mu.Lock()
+	if u.closed {
+		u.mu.Unlock()
+		return fmt.Errorf("failed to create webhook server: %w", UpdaterStoppedError)
+	}
+	server, ok := u.servers[route.addr]
+	if ok && !server.sameOptions(opts) {
+		u.mu.Unlock()
+		return fmt.Errorf("failed to start webhook: %s is already served with other server options", route.addr)
+	}
+	if !ok {
+		var err error
+		server, err = u.newWebhookServer(route.addr, opts)
+		if err != nil {
+			u.mu.Unlock()
+			return fmt.Errorf("failed to create webhook server: %w", err)
+		}
+	}
+	route.server = server
+	if !server.add(route.path, u.webhookHandler(bot, opts)) {
+		u.mu.Unlock()
+		return fmt.Errorf("failed to start webhook: %s is already served on %s", route.path, route.addr)
+	}
+	_, existing := u.bots[bot]
+	b := u.getOrAddBot(bot)
+	b.webhooks = append(b.webhooks, route)
+	u.mu.Unlock()
+
+	if opts.URL != "" {
+		if err := u.SubscribeWebhook(bot, opts); err != nil {
+			u.removeWebhook(bot, route, !existing)
+			return err
+		}
+	}
+	return nil
+}
+
+// removeWebhook undoes the registration of a webhook by StartWebhook, closing the server
+// if it has no path left. The bot is removed too if it was added for the webhook and has nothing else.
+func (u *Updater) removeWebhook(bot *gottbot.Bot, route webhookRoute, removeBot bool) {
+	var server *http.Server
+	var removed *updaterBot
+	u.mu.Lock()
+	if route.server.remove(route.path) == 0 && u.servers[route.addr] == route.server {
+		delete(u.servers, route.addr)
+		server = route.server.server
+	}
+	if b, ok := u.bots[bot]; ok {
+		for i, r := range b.webhooks {
+			if r == route {
+				b.webhooks = append(b.webhooks[:i:i], b.webhooks[i+1:]...)
+				break
+			}
+		}
+		if removeBot && !b.polling && len(b.webhooks) == 0 {
+			delete(u.bots, bot)
+			removed = b
+		}
+	}
+	u.mu.Unlock()
+	if removed != nil {
+		removed.close()
+	}
+	if server != nil {
+		if err := server.Close(); err != nil {
+			u.logger.Error("failed to close webhook server", "addr", server.Addr, "error", err)
+		}
+	}
+}
+
+// newWebhookServer listens on addr and starts serving the webhooks, the TLS key pair is loaded
+// beforehand so that its errors are returned. mu must be held for writing.
+func (u *Updater) newWebhookServer(addr string, opts *WebhookOpts) (*webhookServer, error) {
+	var tlsConfig *tls.Config
+	if opts.CertFile != "" {
+		certificate, err := tls.LoadX509KeyPair(opts.CertFile, opts.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig = &tls.Config{Certificates: []tls.Certificate{certificate}}
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		listener = tls.NewListener(listener, tlsConfig)
+	}
+	server := &webhookServer{
+		opts:     *opts,
+		handlers: make(map[string]http.Handler),
+	}
+	server.server = &http.Server{
+		Addr:        addr,
+		Handler:     server,
+		ReadTimeout: opts.ReadTimeout,
+		TLSConfig:   tlsConfig,
+	}
+	u.servers[addr] = server
+	go u.serveWebhook(server, listener)
+	return server, nil
+}
+
+func (u *Updater) serveWebhook(server *webhookServer, listener net.Listener) {
+	err := server.server.Serve(listener)
+	if err == nil || errors.Is(err, http.ErrServerClosed) {
+		return
+	}
+	u.logger.Error("webhook server failed", "addr", server.server.Addr, "error", err)
+	// don't let the next webhooks on this address attach to a dead server
+	u.mu.Lock()
+	if u.servers[server.server.Addr] == server {
+		delete(u.servers, server.server.Addr)
+	}
+	u.mu.Unlock()
+}
+
+// WebhookHandler returns an http.Handler receiving the webhook updates of the bot,
+// to be mounted on an existing server. Only the request options of opts are used:
+// Secret, SecretHeader and MaxBodySize. opts can be nil.
+//
+// The bot is not subscribed to the webhook, use SubscribeWebhook or Bot.Subscribe for that.
+func (u *Updater) WebhookHandler(bot *gottbot.Bot, opts *WebhookOpts) http.Handler {
+	if opts == nil {
+		opts = new(WebhookOpts)
+	}
+	u.mu.Lock()
+	if !u.closed {
+		u.getOrAddBot(bot)
+	}
+	u.mu.Unlock()
+	return u.webhookHandler(bot, opts)
+}
+
+// SubscribeWebhook subscribes the bot to opts.URL, removing its other subscriptions first
+// if opts.DropStaleSubscriptions is set. The subscription is removed when the updater is stopped.
+func (u *Updater) SubscribeWebhook(bot *gottbot.Bot, opts *WebhookOpts) error {
+	if opts.DropStaleSubscriptions {
+		subscriptions, err := bot.GetSubscriptionsCtx(u.ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get webhook subscriptions: %w", err)
+		}
+		for _, subscription := range subscriptions.Subscriptions {
+			if subscription.Url == opts.URL {
+				continue
+			}
+			if _, err := bot.UnsubscribeCtx(u.ctx, subscription.Url); err != nil {
+				return fmt.Errorf("failed to remove stale webhook subscription: %w", err)
+			}
+		}
+	}
+	updateTypes := opts.UpdateTypes
+	if updateTypes == nil {
+		dispatcher := u.Dispatcher
+		u.mu.RLock()
+		if b, ok := u.bots[bot]; ok {
+			dispatcher = b.dispatcher
+		}
+		u.mu.RUnlock()
+		if d, ok := dispatcher.(interface{ GetUpdateTypes() []gottbot.UpdateType }); ok {
+			updateTypes = d.GetUpdateTypes()
+		}
+	}
+	result, err := bot.SubscribeCtx(u.ctx, gottbot.SubscriptionRequestBody{
+		Url:         opts.URL,
+		UpdateTypes: updateTypes,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to webhook: %w", err)
+	}
+	if !result.Success {
+		message := ""
+		if result.Message != nil {
+			message = *result.Message
+		}
+		return fmt.Errorf("failed to subscribe to webhook: %s", message)
+	}
+	u.mu.Lock()
+	if u.closed {
+		// Stop has already removed the subscriptions it knew about
+		u.mu.Unlock()
+		if _, err := bot.Unsubscribe(opts.URL); err != nil {
+			u.logger.Error("failed to remove webhook subscription", "url", opts.URL, "error", err)
+		}
+		return fmt.Errorf("failed to subscribe to webhook: %w", UpdaterStoppedError)
+	}
+	u.subscriptions = append(u.subscriptions, webhookSubscription{bot, opts.URL})
+	u.mu.Unlock()
+	return nil
+}
+
+// webhookHandler accepts the updates POSTed by the server, it responds with 200 OK
+// only once the update has been handed over to the dispatcher.
+func (u *Updater) webhookHandler(bot *gottbot.Bot, opts *WebhookOpts) http.Handler {
+	secretHeader := opts.SecretHeader
+	if secretHeader == "" {
+		secretHeader = DefaultWebhookSecretHeader
+	}
+	maxBodySize := opts.MaxBodySize
+	if maxBodySize == 0 {
+		maxBodySize = DefaultWebhookMaxBodySize
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if opts.Secret != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get(secretHeader)), []byte(opts.Secret)) != 1 {
+			http.Error(w, "invalid secret", http.StatusUnauthorized)
+			return
+		}
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+		if err != nil {
+			// http.MaxBytesError is not available before Go 1.19
+			if err.Error() == "http: request body too large" {
+				http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+			} else {
+				http.Error(w, "failed to read request body", http.StatusBadRequest)
+			}
+			return
+		}
+		header := struct {
+			UpdateType gottbot.UpdateType `json:"update_type"`
+		}{}
+		if err := json.Unmarshal(body, &header); err != nil || header.UpdateType == "" {
+			u.logger.Warn("dropped invalid webhook update", "remote_addr", r.RemoteAddr, "error", err)
+			http.Error(w, "invalid update", http.StatusBadRequest)
+			return
+		}
+		var update gottbot.Update
+		if err := json.Unmarshal(body, &update); err != nil {
+			u.logger.Warn("dropped invalid webhook update", "update_type", header.UpdateType, "error", err)
+			http.Error(w, "invalid update", http.StatusBadRequest)
+			return
+		}
+		if !u.send(bot, &update) {
+			u.logger.Warn("dropped update, the updater is stopping", "update_type", header.UpdateType)
+			http.Error(w, "shutting down", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
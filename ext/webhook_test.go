@@ -0,0 +1,154 @@
+package ext
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anonyindian/gottbot"
+)
+
+// writeKeyPair writes a self-signed certificate for 127.0.0.1 and its key to dir.
+func writeKeyPair(t *testing.T, dir string) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile, keyFile = filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+func newTestBot(t *testing.T) *gottbot.Bot {
+	bot, err := gottbot.NewBot("token", &gottbot.BotOpts{DisableTokenVerification: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bot
+}
+
+func TestStartWebhookTLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeKeyPair(t, dir)
+	updater := NewUpdater(&UpdaterOpts{Logger: gottbot.NopLogger})
+	defer updater.Stop(context.Background())
+	bot := newTestBot(t)
+
+	tests := []struct {
+		name     string
+		certFile string
+		keyFile  string
+	}{
+		{name: "missing files", certFile: filepath.Join(dir, "missing.pem"), keyFile: filepath.Join(dir, "missing.key")},
+		{name: "only cert", certFile: certFile},
+		{name: "only key", keyFile: keyFile},
+		{name: "mismatched files", certFile: keyFile, keyFile: certFile},
+	}
+	for _, tt := range tests {
+		port := freePort(t)
+		err := updater.StartWebhook(bot, &WebhookOpts{Domain: "127.0.0.1", Port: port, CertFile: tt.certFile, KeyFile: tt.keyFile})
+		if err == nil {
+			t.Errorf("%s: StartWebhook returned no error", tt.name)
+		}
+		if isListening(port) {
+			t.Errorf("%s: the webhook server is listening", tt.name)
+		}
+	}
+
+	port := freePort(t)
+	if err := updater.StartWebhook(bot, &WebhookOpts{Domain: "127.0.0.1", Port: port, CertFile: certFile, KeyFile: keyFile}); err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+	resp, err := client.Get(fmt.Sprintf("https://127.0.0.1:%d/", port))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("GET over TLS responded %d, want 405", resp.StatusCode)
+	}
+}
+
+// failingReader fails like a client disconnecting in the middle of the body.
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("connection reset by peer")
+}
+
+func TestWebhookHandlerBodyErrors(t *testing.T) {
+	updater := NewUpdater(&UpdaterOpts{Logger: gottbot.NopLogger})
+	defer updater.Stop(context.Background())
+	handler := updater.WebhookHandler(newTestBot(t), &WebhookOpts{MaxBodySize: 16})
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(strings.Repeat("x", 17))))
+	if recorder.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("oversized body responded %d, want 413", recorder.Code)
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/", failingReader{}))
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("failed read responded %d, want 400", recorder.Code)
+	}
+}
+
+func TestStartWebhookRejectsOtherServerOptions(t *testing.T) {
+	certFile, keyFile := writeKeyPair(t, t.TempDir())
+	updater := NewUpdater(&UpdaterOpts{Logger: gottbot.NopLogger})
+	defer updater.Stop(context.Background())
+	port := freePort(t)
+	if err := updater.StartWebhook(newTestBot(t), &WebhookOpts{Domain: "127.0.0.1", Port: port, Path: "a"}); err != nil {
+		t.Fatal(err)
+	}
+	tests := []*WebhookOpts{
+		{Domain: "127.0.0.1", Port: port, Path: "b", CertFile: certFile, KeyFile: keyFile},
+		{Domain: "127.0.0.1", Port: port, Path: "c", ReadTimeout: time.Second},
+	}
+	for _, opts := range tests {
+		if err := updater.StartWebhook(newTestBot(t), opts); err == nil {
+			t.Errorf("StartWebhook on /%s with other server options returned no error", opts.Path)
+		}
+	}
+	if err := updater.StartWebhook(newTestBot(t), &WebhookOpts{Domain: "127.0.0.1", Port: port, Path: "d"}); err != nil {
+		t.Errorf("StartWebhook with the same server options: %v", err)
+	}
+}
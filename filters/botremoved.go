@@ -0,0 +1,25 @@
+package filters
+
+import "github.com/anonyindian/gottbot"
+
+type BotRemovedFilter func(u *gottbot.BotRemoved) bool
+
+func (*botRemoved) All(_ *gottbot.BotRemoved) bool {
+	return true
+}
+
+func (*botRemoved) Chat(chatId int64) BotRemovedFilter {
+	return func(u *gottbot.BotRemoved) bool {
+		return u.ChatId == chatId
+	}
+}
+
+func (*botRemoved) User(userId int64) BotRemovedFilter {
+	return func(u *gottbot.BotRemoved) bool {
+		return u.User != nil && u.User.UserId == userId
+	}
+}
+
+func (*botRemoved) IsChannel(u *gottbot.BotRemoved) bool {
+	return u.IsChannel
+}
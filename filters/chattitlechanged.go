@@ -0,0 +1,27 @@
+package filters
+
+import "github.com/anonyindian/gottbot"
+
+type ChatTitleChangedFilter func(u *gottbot.ChatTitleChanged) bool
+
+func (*chatTitleChanged) All(_ *gottbot.ChatTitleChanged) bool {
+	return true
+}
+
+func (*chatTitleChanged) Chat(chatId int64) ChatTitleChangedFilter {
+	return func(u *gottbot.ChatTitleChanged) bool {
+		return u.ChatId == chatId
+	}
+}
+
+func (*chatTitleChanged) User(userId int64) ChatTitleChangedFilter {
+	return func(u *gottbot.ChatTitleChanged) bool {
+		return u.User != nil && u.User.UserId == userId
+	}
+}
+
+func (*chatTitleChanged) Title(title string) ChatTitleChangedFilter {
+	return func(u *gottbot.ChatTitleChanged) bool {
+		return u.Title == title
+	}
+}
@@ -3,11 +3,29 @@ package filters
 type filter int
 
 type (
-	message       filter
-	callbackQuery filter
+	message                    filter
+	callbackQuery              filter
+	messageEdited              filter
+	messageRemoved             filter
+	botRemoved                 filter
+	userAdded                  filter
+	userRemoved                filter
+	chatTitleChanged           filter
+	messageChatCreated         filter
+	messageConstructionRequest filter
+	messageConstructed         filter
 )
 
 var (
-	Message       = new(message)
-	CallbackQuery = new(callbackQuery)
+	Message                    = new(message)
+	CallbackQuery              = new(callbackQuery)
+	MessageEdited              = new(messageEdited)
+	MessageRemoved             = new(messageRemoved)
+	BotRemoved                 = new(botRemoved)
+	UserAdded                  = new(userAdded)
+	UserRemoved                = new(userRemoved)
+	ChatTitleChanged           = new(chatTitleChanged)
+	MessageChatCreated         = new(messageChatCreated)
+	MessageConstructionRequest = new(messageConstructionRequest)
+	MessageConstructed         = new(messageConstructed)
 )
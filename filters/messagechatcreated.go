@@ -0,0 +1,21 @@
+package filters
+
+import "github.com/anonyindian/gottbot"
+
+type MessageChatCreatedFilter func(u *gottbot.MessageChatCreated) bool
+
+func (*messageChatCreated) All(_ *gottbot.MessageChatCreated) bool {
+	return true
+}
+
+func (*messageChatCreated) Chat(chatId int64) MessageChatCreatedFilter {
+	return func(u *gottbot.MessageChatCreated) bool {
+		return u.Chat != nil && u.Chat.ChatId == chatId
+	}
+}
+
+func (*messageChatCreated) StartPayload(payload string) MessageChatCreatedFilter {
+	return func(u *gottbot.MessageChatCreated) bool {
+		return u.StartPayload == payload
+	}
+}
@@ -0,0 +1,22 @@
+package filters
+
+import "github.com/anonyindian/gottbot"
+
+type MessageConstructedFilter func(u *gottbot.MessageConstructed) bool
+
+func (*messageConstructed) All(_ *gottbot.MessageConstructed) bool {
+	return true
+}
+
+func (*messageConstructed) Session(sessionId string) MessageConstructedFilter {
+	return func(u *gottbot.MessageConstructed) bool {
+		return u.SessionId == sessionId
+	}
+}
+
+// Message applies the message filter to the message of the update.
+func (*messageConstructed) Message(filter MessageFilter) MessageConstructedFilter {
+	return func(u *gottbot.MessageConstructed) bool {
+		return u.Message != nil && filter(u.Message)
+	}
+}
@@ -0,0 +1,21 @@
+package filters
+
+import "github.com/anonyindian/gottbot"
+
+type MessageConstructionRequestFilter func(u *gottbot.MessageConstructionRequest) bool
+
+func (*messageConstructionRequest) All(_ *gottbot.MessageConstructionRequest) bool {
+	return true
+}
+
+func (*messageConstructionRequest) User(userId int64) MessageConstructionRequestFilter {
+	return func(u *gottbot.MessageConstructionRequest) bool {
+		return u.User != nil && u.User.UserId == userId
+	}
+}
+
+func (*messageConstructionRequest) Session(sessionId string) MessageConstructionRequestFilter {
+	return func(u *gottbot.MessageConstructionRequest) bool {
+		return u.SessionId == sessionId
+	}
+}
@@ -0,0 +1,16 @@
+package filters
+
+import "github.com/anonyindian/gottbot"
+
+type MessageEditedFilter func(u *gottbot.MessageEdited) bool
+
+func (*messageEdited) All(_ *gottbot.MessageEdited) bool {
+	return true
+}
+
+// Message applies the message filter to the message of the update.
+func (*messageEdited) Message(filter MessageFilter) MessageEditedFilter {
+	return func(u *gottbot.MessageEdited) bool {
+		return u.Message != nil && filter(u.Message)
+	}
+}
@@ -0,0 +1,21 @@
+package filters
+
+import "github.com/anonyindian/gottbot"
+
+type MessageRemovedFilter func(u *gottbot.MessageRemoved) bool
+
+func (*messageRemoved) All(_ *gottbot.MessageRemoved) bool {
+	return true
+}
+
+func (*messageRemoved) Chat(chatId int64) MessageRemovedFilter {
+	return func(u *gottbot.MessageRemoved) bool {
+		return u.ChatId == chatId
+	}
+}
+
+func (*messageRemoved) User(userId int64) MessageRemovedFilter {
+	return func(u *gottbot.MessageRemoved) bool {
+		return u.UserId == userId
+	}
+}
@@ -0,0 +1,31 @@
+package filters
+
+import "github.com/anonyindian/gottbot"
+
+type UserAddedFilter func(u *gottbot.UserAdded) bool
+
+func (*userAdded) All(_ *gottbot.UserAdded) bool {
+	return true
+}
+
+func (*userAdded) Chat(chatId int64) UserAddedFilter {
+	return func(u *gottbot.UserAdded) bool {
+		return u.ChatId == chatId
+	}
+}
+
+func (*userAdded) User(userId int64) UserAddedFilter {
+	return func(u *gottbot.UserAdded) bool {
+		return u.User != nil && u.User.UserId == userId
+	}
+}
+
+func (*userAdded) Inviter(userId int64) UserAddedFilter {
+	return func(u *gottbot.UserAdded) bool {
+		return u.InviderId == userId
+	}
+}
+
+func (*userAdded) IsChannel(u *gottbot.UserAdded) bool {
+	return u.IsChannel
+}
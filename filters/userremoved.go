@@ -0,0 +1,31 @@
+package filters
+
+import "github.com/anonyindian/gottbot"
+
+type UserRemovedFilter func(u *gottbot.UserRemoved) bool
+
+func (*userRemoved) All(_ *gottbot.UserRemoved) bool {
+	return true
+}
+
+func (*userRemoved) Chat(chatId int64) UserRemovedFilter {
+	return func(u *gottbot.UserRemoved) bool {
+		return u.ChatId == chatId
+	}
+}
+
+func (*userRemoved) User(userId int64) UserRemovedFilter {
+	return func(u *gottbot.UserRemoved) bool {
+		return u.User != nil && u.User.UserId == userId
+	}
+}
+
+func (*userRemoved) Admin(userId int64) UserRemovedFilter {
+	return func(u *gottbot.UserRemoved) bool {
+		return u.AdminId == userId
+	}
+}
+
+func (*userRemoved) IsChannel(u *gottbot.UserRemoved) bool {
+	return u.IsChannel
+}
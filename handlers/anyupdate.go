@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"github.com/anonyindian/gottbot"
+	"github.com/anonyindian/gottbot/ext"
+)
+
+// AnyUpdate handles every update, including the ones of the types unknown to the library.
+type AnyUpdate struct {
+	Response  Callback
+	handlerID string
+}
+
+func AnyUpdateHandler(callback Callback) *AnyUpdate {
+	return &AnyUpdate{
+		Response:  callback,
+		handlerID: makeHandlerID("any_update"),
+	}
+}
+
+func (m *AnyUpdate) CheckUpdate(_ *gottbot.Update) bool {
+	return true
+}
+
+func (m *AnyUpdate) HandleUpdate(bot *gottbot.Bot, ctx *ext.Context) error {
+	return m.Response(bot, ctx)
+}
+
+func (m *AnyUpdate) GetHandlerID() ext.HandlerID {
+	if m.handlerID == "" {
+		m.handlerID = makeHandlerID("any_update")
+	}
+	return ext.HandlerID(m.handlerID)
+}
+
+// SetName uses the provided name as the HandlerID of the handler.
+func (m *AnyUpdate) SetName(name string) *AnyUpdate {
+	m.handlerID = name
+	return m
+}
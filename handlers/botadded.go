@@ -1,8 +1,6 @@
 package handlers
 
 import (
-	"fmt"
-
 	"github.com/anonyindian/gottbot"
 	"github.com/anonyindian/gottbot/ext"
 )
@@ -14,7 +12,8 @@ type BotAdded struct {
 
 func BotAddedHandler(callback Callback) *BotAdded {
 	return &BotAdded{
-		Response: callback,
+		Response:  callback,
+		handlerID: makeHandlerID("bot_added"),
 	}
 }
 
@@ -22,13 +21,23 @@ func (m *BotAdded) CheckUpdate(update *gottbot.Update) bool {
 	return update.GetUpdateType() == gottbot.UpdateTypeBotAdded
 }
 
+func (m *BotAdded) GetUpdateTypes() []gottbot.UpdateType {
+	return []gottbot.UpdateType{gottbot.UpdateTypeBotAdded}
+}
+
 func (m *BotAdded) HandleUpdate(bot *gottbot.Bot, ctx *ext.Context) error {
 	return m.Response(bot, ctx)
 }
 
 func (m *BotAdded) GetHandlerID() ext.HandlerID {
 	if m.handlerID == "" {
-		m.handlerID = makeHandlerID("bot_added", fmt.Sprintf("%v", m.Response))
+		m.handlerID = makeHandlerID("bot_added")
 	}
 	return ext.HandlerID(m.handlerID)
 }
+
+// SetName uses the provided name as the HandlerID of the handler.
+func (m *BotAdded) SetName(name string) *BotAdded {
+	m.handlerID = name
+	return m
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"github.com/anonyindian/gottbot"
+	"github.com/anonyindian/gottbot/ext"
+	"github.com/anonyindian/gottbot/filters"
+)
+
+type BotRemoved struct {
+	Response Callback
+	// Filter is optional, every update passes a nil filter.
+	Filter    filters.BotRemovedFilter
+	handlerID string
+}
+
+func BotRemovedHandler(filter filters.BotRemovedFilter, callback Callback) *BotRemoved {
+	return &BotRemoved{
+		Response:  callback,
+		Filter:    filter,
+		handlerID: makeHandlerID("bot_removed"),
+	}
+}
+
+func (m *BotRemoved) CheckUpdate(update *gottbot.Update) bool {
+	return update.BotRemoved != nil
+}
+
+func (m *BotRemoved) GetUpdateTypes() []gottbot.UpdateType {
+	return []gottbot.UpdateType{gottbot.UpdateTypeBotRemoved}
+}
+
+func (m *BotRemoved) HandleUpdate(bot *gottbot.Bot, ctx *ext.Context) error {
+	if m.Filter != nil && !m.Filter(ctx.BotRemoved) {
+		return ext.ContinueGroup
+	}
+	return m.Response(bot, ctx)
+}
+
+func (m *BotRemoved) GetHandlerID() ext.HandlerID {
+	if m.handlerID == "" {
+		m.handlerID = makeHandlerID("bot_removed")
+	}
+	return ext.HandlerID(m.handlerID)
+}
+
+// SetName uses the provided name as the HandlerID of the handler.
+func (m *BotRemoved) SetName(name string) *BotRemoved {
+	m.handlerID = name
+	return m
+}
@@ -1,8 +1,6 @@
 package handlers
 
 import (
-	"fmt"
-
 	"github.com/anonyindian/gottbot"
 	"github.com/anonyindian/gottbot/ext"
 )
@@ -14,7 +12,8 @@ type BotStarted struct {
 
 func BotStartedHandler(callback Callback) *BotStarted {
 	return &BotStarted{
-		Response: callback,
+		Response:  callback,
+		handlerID: makeHandlerID("bot_started"),
 	}
 }
 
@@ -22,13 +21,23 @@ func (m *BotStarted) CheckUpdate(update *gottbot.Update) bool {
 	return update.GetUpdateType() == gottbot.UpdateTypeBotStarted
 }
 
+func (m *BotStarted) GetUpdateTypes() []gottbot.UpdateType {
+	return []gottbot.UpdateType{gottbot.UpdateTypeBotStarted}
+}
+
 func (m *BotStarted) HandleUpdate(bot *gottbot.Bot, ctx *ext.Context) error {
 	return m.Response(bot, ctx)
 }
 
 func (m *BotStarted) GetHandlerID() ext.HandlerID {
 	if m.handlerID == "" {
-		m.handlerID = makeHandlerID("bot_started", fmt.Sprintf("%v", m.Response))
+		m.handlerID = makeHandlerID("bot_started")
 	}
 	return ext.HandlerID(m.handlerID)
 }
+
+// SetName uses the provided name as the HandlerID of the handler.
+func (m *BotStarted) SetName(name string) *BotStarted {
+	m.handlerID = name
+	return m
+}
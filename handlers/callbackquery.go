@@ -1,8 +1,6 @@
 package handlers
 
 import (
-	"fmt"
-
 	"github.com/anonyindian/gottbot"
 	"github.com/anonyindian/gottbot/ext"
 	"github.com/anonyindian/gottbot/filters"
@@ -16,8 +14,9 @@ type CallbackQuery struct {
 
 func CallbackQueryHandler(filter filters.CallbackQueryFilter, callback Callback) *CallbackQuery {
 	return &CallbackQuery{
-		Response: callback,
-		Filter:   filter,
+		Response:  callback,
+		Filter:    filter,
+		handlerID: makeHandlerID("callback_query"),
 	}
 }
 
@@ -29,6 +28,10 @@ func (m *CallbackQuery) CheckUpdate(update *gottbot.Update) bool {
 	return false
 }
 
+func (m *CallbackQuery) GetUpdateTypes() []gottbot.UpdateType {
+	return []gottbot.UpdateType{gottbot.UpdateTypeMessageCallback}
+}
+
 func (m *CallbackQuery) HandleUpdate(bot *gottbot.Bot, ctx *ext.Context) error {
 	if !m.Filter(ctx.EffectiveQuery) {
 		return ext.ContinueGroup
@@ -38,7 +41,13 @@ func (m *CallbackQuery) HandleUpdate(bot *gottbot.Bot, ctx *ext.Context) error {
 
 func (m *CallbackQuery) GetHandlerID() ext.HandlerID {
 	if m.handlerID == "" {
-		m.handlerID = makeHandlerID("callback_query", fmt.Sprintf("%v", m.Response))
+		m.handlerID = makeHandlerID("callback_query")
 	}
 	return ext.HandlerID(m.handlerID)
 }
+
+// SetName uses the provided name as the HandlerID of the handler.
+func (m *CallbackQuery) SetName(name string) *CallbackQuery {
+	m.handlerID = name
+	return m
+}
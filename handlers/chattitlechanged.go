@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"github.com/anonyindian/gottbot"
+	"github.com/anonyindian/gottbot/ext"
+	"github.com/anonyindian/gottbot/filters"
+)
+
+type ChatTitleChanged struct {
+	Response Callback
+	// Filter is optional, every update passes a nil filter.
+	Filter    filters.ChatTitleChangedFilter
+	handlerID string
+}
+
+func ChatTitleChangedHandler(filter filters.ChatTitleChangedFilter, callback Callback) *ChatTitleChanged {
+	return &ChatTitleChanged{
+		Response:  callback,
+		Filter:    filter,
+		handlerID: makeHandlerID("chat_title_changed"),
+	}
+}
+
+func (m *ChatTitleChanged) CheckUpdate(update *gottbot.Update) bool {
+	return update.ChatTitleChanged != nil
+}
+
+func (m *ChatTitleChanged) GetUpdateTypes() []gottbot.UpdateType {
+	return []gottbot.UpdateType{gottbot.UpdateTypeChatTitleChanged}
+}
+
+func (m *ChatTitleChanged) HandleUpdate(bot *gottbot.Bot, ctx *ext.Context) error {
+	if m.Filter != nil && !m.Filter(ctx.ChatTitleChanged) {
+		return ext.ContinueGroup
+	}
+	return m.Response(bot, ctx)
+}
+
+func (m *ChatTitleChanged) GetHandlerID() ext.HandlerID {
+	if m.handlerID == "" {
+		m.handlerID = makeHandlerID("chat_title_changed")
+	}
+	return ext.HandlerID(m.handlerID)
+}
+
+// SetName uses the provided name as the HandlerID of the handler.
+func (m *ChatTitleChanged) SetName(name string) *ChatTitleChanged {
+	m.handlerID = name
+	return m
+}
@@ -1,27 +1,40 @@
 package handlers
 
 import (
-	"fmt"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/anonyindian/gottbot"
 	"github.com/anonyindian/gottbot/ext"
 	"github.com/anonyindian/gottbot/filters"
 )
 
+// Command handles the messages starting with a command, e.g. /start or /start@mybot.
+// The parsed arguments of the command are set in ctx.Args while the callback runs.
+//
+// A command mentioning a bot is only handled if the mention matches the username of the bot,
+// so it is never handled by a bot whose info is unknown (see gottbot.BotOpts.DisableTokenVerification).
 type Command struct {
-	Prefix    []rune
-	Command   string
-	Response  Callback
-	Filter    filters.MessageFilter
-	handlerID string
+	Prefix  []rune
+	Command string
+	// Aliases are the other names the command can be called with.
+	Aliases []string
+	// CaseSensitive matches the names of the command exactly, they are case-insensitive by default.
+	CaseSensitive bool
+	// AllowEdited also handles the commands of the edited messages.
+	AllowEdited bool
+	Response    Callback
+	Filter      filters.MessageFilter
+	handlerID   string
 }
 
 func CommandHandler(command string, callback Callback) *Command {
 	return &Command{
-		Prefix:   []rune{'/'},
-		Command:  command,
-		Response: callback,
+		Prefix:    []rune{'/'},
+		Command:   command,
+		Response:  callback,
+		handlerID: makeHandlerID("command"),
 	}
 }
 
@@ -35,38 +48,129 @@ func (c *Command) SetFilter(filter filters.MessageFilter) *Command {
 	return c
 }
 
-func (c *Command) checkCommand(text string) bool {
-	if text == "" {
-		return false
+// SetAliases sets the other names the command can be called with.
+func (c *Command) SetAliases(aliases ...string) *Command {
+	c.Aliases = aliases
+	return c
+}
+
+// SetCaseSensitive makes the names of the command case-sensitive.
+func (c *Command) SetCaseSensitive(caseSensitive bool) *Command {
+	c.CaseSensitive = caseSensitive
+	return c
+}
+
+// SetAllowEdited makes the handler handle the commands of the edited messages too.
+func (c *Command) SetAllowEdited(allowEdited bool) *Command {
+	c.AllowEdited = allowEdited
+	return c
+}
+
+// parseCommand parses the command at the start of the text, it returns nil
+// if the text doesn't start with one of the names of the command.
+func (c *Command) parseCommand(text string) *ext.CommandArgs {
+	text = strings.TrimLeftFunc(text, unicode.IsSpace)
+	prefix, size := utf8.DecodeRuneInString(text)
+	if size == 0 || !c.hasPrefix(prefix) {
+		return nil
 	}
-	arg := strings.ToLower(strings.Fields(text)[0])
+	text = text[size:]
+	name, raw := text, ""
+	if i := strings.IndexFunc(text, unicode.IsSpace); i >= 0 {
+		name, raw = text[:i], strings.TrimSpace(text[i:])
+	}
+	args := &ext.CommandArgs{Raw: raw}
+	if i := strings.IndexByte(name, '@'); i >= 0 {
+		name, args.Mention = name[:i], name[i+1:]
+	}
+	if !c.matchName(name) {
+		return nil
+	}
+	args.Command = name
+	return args
+}
+
+func (c *Command) hasPrefix(r rune) bool {
 	for _, prefix := range c.Prefix {
-		if rune(arg[0]) != prefix {
-			continue
+		if r == prefix {
+			return true
 		}
-		if arg[1:] != c.Command {
-			continue
+	}
+	return false
+}
+
+func (c *Command) matchName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, command := range append([]string{c.Command}, c.Aliases...) {
+		if c.CaseSensitive && name == command || !c.CaseSensitive && strings.EqualFold(name, command) {
+			return true
 		}
-		return true
 	}
 	return false
 }
 
+// mentionsBot reports whether a command addressed to mention is addressed to the bot,
+// the commands without a mention are addressed to every bot.
+// A mention can't match if the username of the bot is unknown.
+func mentionsBot(bot *gottbot.Bot, mention string) bool {
+	if mention == "" {
+		return true
+	}
+	if bot.BotInfo == nil || bot.BotInfo.Username == nil {
+		return false
+	}
+	return strings.EqualFold(mention, strings.TrimPrefix(*bot.BotInfo.Username, "@"))
+}
+
+func (c *Command) message(update *gottbot.Update) *gottbot.Message {
+	switch {
+	case update.MessageCreated != nil:
+		return update.MessageCreated.Message
+	case update.MessageEdited != nil && c.AllowEdited:
+		return update.MessageEdited.Message
+	}
+	return nil
+}
+
 func (c *Command) CheckUpdate(update *gottbot.Update) bool {
-	switch update.GetUpdateType() {
-	case gottbot.UpdateTypeMessageCreated:
-		return c.checkCommand(update.MessageCreated.Message.Body.Text)
+	message := c.message(update)
+	return message != nil && c.parseCommand(message.Body.Text) != nil
+}
+
+func (c *Command) GetUpdateTypes() []gottbot.UpdateType {
+	if c.AllowEdited {
+		return []gottbot.UpdateType{gottbot.UpdateTypeMessageCreated, gottbot.UpdateTypeMessageEdited}
 	}
-	return false
+	return []gottbot.UpdateType{gottbot.UpdateTypeMessageCreated}
 }
 
 func (c *Command) HandleUpdate(bot *gottbot.Bot, ctx *ext.Context) error {
+	args := c.parseCommand(ctx.EffectiveMessage.Body.Text)
+	if args == nil || !mentionsBot(bot, args.Mention) {
+		return ext.ContinueGroup
+	}
+	if c.Filter != nil && !c.Filter(ctx.EffectiveMessage) {
+		return ext.ContinueGroup
+	}
+	// the context is shared by the handlers of the update, don't leak the args to the next ones
+	ctx.Args = args
+	defer func() {
+		ctx.Args = nil
+	}()
 	return c.Response(bot, ctx)
 }
 
 func (c *Command) GetHandlerID() ext.HandlerID {
 	if c.handlerID == "" {
-		c.handlerID = makeHandlerID("command", fmt.Sprintf("%v", c.Response))
+		c.handlerID = makeHandlerID("command")
 	}
 	return ext.HandlerID(c.handlerID)
 }
+
+// SetName uses the provided name as the HandlerID of the handler.
+func (c *Command) SetName(name string) *Command {
+	c.handlerID = name
+	return c
+}
@@ -2,7 +2,7 @@ package handlers
 
 import (
 	"fmt"
-	"time"
+	"sync/atomic"
 
 	"github.com/anonyindian/gottbot"
 	"github.com/anonyindian/gottbot/ext"
@@ -10,6 +10,9 @@ import (
 
 type Callback func(bot *gottbot.Bot, ctx *ext.Context) error
 
-func makeHandlerID(name, suffix string) string {
-	return fmt.Sprintf("%d_%s=%s", time.Now().Unix(), name, suffix)
+// handlerCount makes the generated handler ids unique within the process.
+var handlerCount uint64
+
+func makeHandlerID(name string) string {
+	return fmt.Sprintf("%s_%d", name, atomic.AddUint64(&handlerCount, 1))
 }
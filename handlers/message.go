@@ -1,8 +1,6 @@
 package handlers
 
 import (
-	"fmt"
-
 	"github.com/anonyindian/gottbot"
 	"github.com/anonyindian/gottbot/ext"
 	"github.com/anonyindian/gottbot/filters"
@@ -17,8 +15,9 @@ type Message struct {
 
 func MessageHandler(filter filters.MessageFilter, callback Callback) *Message {
 	return &Message{
-		Response: callback,
-		Filter:   filter,
+		Response:  callback,
+		Filter:    filter,
+		handlerID: makeHandlerID("message"),
 	}
 }
 
@@ -32,6 +31,13 @@ func (m *Message) CheckUpdate(update *gottbot.Update) bool {
 	return false
 }
 
+func (m *Message) GetUpdateTypes() []gottbot.UpdateType {
+	if m.AllowEdited {
+		return []gottbot.UpdateType{gottbot.UpdateTypeMessageCreated, gottbot.UpdateTypeMessageEdited}
+	}
+	return []gottbot.UpdateType{gottbot.UpdateTypeMessageCreated}
+}
+
 func (m *Message) HandleUpdate(bot *gottbot.Bot, ctx *ext.Context) error {
 	if !m.Filter(ctx.EffectiveMessage) {
 		return ext.ContinueGroup
@@ -41,7 +47,13 @@ func (m *Message) HandleUpdate(bot *gottbot.Bot, ctx *ext.Context) error {
 
 func (m *Message) GetHandlerID() ext.HandlerID {
 	if m.handlerID == "" {
-		m.handlerID = makeHandlerID("message", fmt.Sprintf("%v", m.Response))
+		m.handlerID = makeHandlerID("message")
 	}
 	return ext.HandlerID(m.handlerID)
 }
+
+// SetName uses the provided name as the HandlerID of the handler.
+func (m *Message) SetName(name string) *Message {
+	m.handlerID = name
+	return m
+}
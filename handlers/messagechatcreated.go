@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"github.com/anonyindian/gottbot"
+	"github.com/anonyindian/gottbot/ext"
+	"github.com/anonyindian/gottbot/filters"
+)
+
+type MessageChatCreated struct {
+	Response Callback
+	// Filter is optional, every update passes a nil filter.
+	Filter    filters.MessageChatCreatedFilter
+	handlerID string
+}
+
+func MessageChatCreatedHandler(filter filters.MessageChatCreatedFilter, callback Callback) *MessageChatCreated {
+	return &MessageChatCreated{
+		Response:  callback,
+		Filter:    filter,
+		handlerID: makeHandlerID("message_chat_created"),
+	}
+}
+
+func (m *MessageChatCreated) CheckUpdate(update *gottbot.Update) bool {
+	return update.MessageChatCreated != nil
+}
+
+func (m *MessageChatCreated) GetUpdateTypes() []gottbot.UpdateType {
+	return []gottbot.UpdateType{gottbot.UpdateTypeMessageChatCreated}
+}
+
+func (m *MessageChatCreated) HandleUpdate(bot *gottbot.Bot, ctx *ext.Context) error {
+	if m.Filter != nil && !m.Filter(ctx.MessageChatCreated) {
+		return ext.ContinueGroup
+	}
+	return m.Response(bot, ctx)
+}
+
+func (m *MessageChatCreated) GetHandlerID() ext.HandlerID {
+	if m.handlerID == "" {
+		m.handlerID = makeHandlerID("message_chat_created")
+	}
+	return ext.HandlerID(m.handlerID)
+}
+
+// SetName uses the provided name as the HandlerID of the handler.
+func (m *MessageChatCreated) SetName(name string) *MessageChatCreated {
+	m.handlerID = name
+	return m
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"github.com/anonyindian/gottbot"
+	"github.com/anonyindian/gottbot/ext"
+	"github.com/anonyindian/gottbot/filters"
+)
+
+type MessageConstructed struct {
+	Response Callback
+	// Filter is optional, every update passes a nil filter.
+	Filter    filters.MessageConstructedFilter
+	handlerID string
+}
+
+func MessageConstructedHandler(filter filters.MessageConstructedFilter, callback Callback) *MessageConstructed {
+	return &MessageConstructed{
+		Response:  callback,
+		Filter:    filter,
+		handlerID: makeHandlerID("message_constructed"),
+	}
+}
+
+func (m *MessageConstructed) CheckUpdate(update *gottbot.Update) bool {
+	return update.MessageConstructed != nil
+}
+
+func (m *MessageConstructed) GetUpdateTypes() []gottbot.UpdateType {
+	return []gottbot.UpdateType{gottbot.UpdateTypeMessageConstructed}
+}
+
+func (m *MessageConstructed) HandleUpdate(bot *gottbot.Bot, ctx *ext.Context) error {
+	if m.Filter != nil && !m.Filter(ctx.MessageConstructed) {
+		return ext.ContinueGroup
+	}
+	return m.Response(bot, ctx)
+}
+
+func (m *MessageConstructed) GetHandlerID() ext.HandlerID {
+	if m.handlerID == "" {
+		m.handlerID = makeHandlerID("message_constructed")
+	}
+	return ext.HandlerID(m.handlerID)
+}
+
+// SetName uses the provided name as the HandlerID of the handler.
+func (m *MessageConstructed) SetName(name string) *MessageConstructed {
+	m.handlerID = name
+	return m
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"github.com/anonyindian/gottbot"
+	"github.com/anonyindian/gottbot/ext"
+	"github.com/anonyindian/gottbot/filters"
+)
+
+type MessageConstructionRequest struct {
+	Response Callback
+	// Filter is optional, every update passes a nil filter.
+	Filter    filters.MessageConstructionRequestFilter
+	handlerID string
+}
+
+func MessageConstructionRequestHandler(filter filters.MessageConstructionRequestFilter, callback Callback) *MessageConstructionRequest {
+	return &MessageConstructionRequest{
+		Response:  callback,
+		Filter:    filter,
+		handlerID: makeHandlerID("message_construction_request"),
+	}
+}
+
+func (m *MessageConstructionRequest) CheckUpdate(update *gottbot.Update) bool {
+	return update.MessageConstructionRequest != nil
+}
+
+func (m *MessageConstructionRequest) GetUpdateTypes() []gottbot.UpdateType {
+	return []gottbot.UpdateType{gottbot.UpdateTypeMessageConstructionRequest}
+}
+
+func (m *MessageConstructionRequest) HandleUpdate(bot *gottbot.Bot, ctx *ext.Context) error {
+	if m.Filter != nil && !m.Filter(ctx.MessageConstructionRequest) {
+		return ext.ContinueGroup
+	}
+	return m.Response(bot, ctx)
+}
+
+func (m *MessageConstructionRequest) GetHandlerID() ext.HandlerID {
+	if m.handlerID == "" {
+		m.handlerID = makeHandlerID("message_construction_request")
+	}
+	return ext.HandlerID(m.handlerID)
+}
+
+// SetName uses the provided name as the HandlerID of the handler.
+func (m *MessageConstructionRequest) SetName(name string) *MessageConstructionRequest {
+	m.handlerID = name
+	return m
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"github.com/anonyindian/gottbot"
+	"github.com/anonyindian/gottbot/ext"
+	"github.com/anonyindian/gottbot/filters"
+)
+
+type MessageEdited struct {
+	Response Callback
+	// Filter is optional, every update passes a nil filter.
+	Filter    filters.MessageEditedFilter
+	handlerID string
+}
+
+func MessageEditedHandler(filter filters.MessageEditedFilter, callback Callback) *MessageEdited {
+	return &MessageEdited{
+		Response:  callback,
+		Filter:    filter,
+		handlerID: makeHandlerID("message_edited"),
+	}
+}
+
+func (m *MessageEdited) CheckUpdate(update *gottbot.Update) bool {
+	return update.MessageEdited != nil
+}
+
+func (m *MessageEdited) GetUpdateTypes() []gottbot.UpdateType {
+	return []gottbot.UpdateType{gottbot.UpdateTypeMessageEdited}
+}
+
+func (m *MessageEdited) HandleUpdate(bot *gottbot.Bot, ctx *ext.Context) error {
+	if m.Filter != nil && !m.Filter(ctx.MessageEdited) {
+		return ext.ContinueGroup
+	}
+	return m.Response(bot, ctx)
+}
+
+func (m *MessageEdited) GetHandlerID() ext.HandlerID {
+	if m.handlerID == "" {
+		m.handlerID = makeHandlerID("message_edited")
+	}
+	return ext.HandlerID(m.handlerID)
+}
+
+// SetName uses the provided name as the HandlerID of the handler.
+func (m *MessageEdited) SetName(name string) *MessageEdited {
+	m.handlerID = name
+	return m
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"github.com/anonyindian/gottbot"
+	"github.com/anonyindian/gottbot/ext"
+	"github.com/anonyindian/gottbot/filters"
+)
+
+type MessageRemoved struct {
+	Response Callback
+	// Filter is optional, every update passes a nil filter.
+	Filter    filters.MessageRemovedFilter
+	handlerID string
+}
+
+func MessageRemovedHandler(filter filters.MessageRemovedFilter, callback Callback) *MessageRemoved {
+	return &MessageRemoved{
+		Response:  callback,
+		Filter:    filter,
+		handlerID: makeHandlerID("message_removed"),
+	}
+}
+
+func (m *MessageRemoved) CheckUpdate(update *gottbot.Update) bool {
+	return update.MessageRemoved != nil
+}
+
+func (m *MessageRemoved) GetUpdateTypes() []gottbot.UpdateType {
+	return []gottbot.UpdateType{gottbot.UpdateTypeMessageRemoved}
+}
+
+func (m *MessageRemoved) HandleUpdate(bot *gottbot.Bot, ctx *ext.Context) error {
+	if m.Filter != nil && !m.Filter(ctx.MessageRemoved) {
+		return ext.ContinueGroup
+	}
+	return m.Response(bot, ctx)
+}
+
+func (m *MessageRemoved) GetHandlerID() ext.HandlerID {
+	if m.handlerID == "" {
+		m.handlerID = makeHandlerID("message_removed")
+	}
+	return ext.HandlerID(m.handlerID)
+}
+
+// SetName uses the provided name as the HandlerID of the handler.
+func (m *MessageRemoved) SetName(name string) *MessageRemoved {
+	m.handlerID = name
+	return m
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"github.com/anonyindian/gottbot"
+	"github.com/anonyindian/gottbot/ext"
+)
+
+// UnknownUpdate handles the updates of the types unknown to the library,
+// their original JSON is available in ctx.Unknown.Raw.
+type UnknownUpdate struct {
+	Response  Callback
+	handlerID string
+}
+
+func UnknownUpdateHandler(callback Callback) *UnknownUpdate {
+	return &UnknownUpdate{
+		Response:  callback,
+		handlerID: makeHandlerID("unknown_update"),
+	}
+}
+
+func (m *UnknownUpdate) CheckUpdate(update *gottbot.Update) bool {
+	return update.Unknown != nil
+}
+
+func (m *UnknownUpdate) HandleUpdate(bot *gottbot.Bot, ctx *ext.Context) error {
+	return m.Response(bot, ctx)
+}
+
+func (m *UnknownUpdate) GetHandlerID() ext.HandlerID {
+	if m.handlerID == "" {
+		m.handlerID = makeHandlerID("unknown_update")
+	}
+	return ext.HandlerID(m.handlerID)
+}
+
+// SetName uses the provided name as the HandlerID of the handler.
+func (m *UnknownUpdate) SetName(name string) *UnknownUpdate {
+	m.handlerID = name
+	return m
+}
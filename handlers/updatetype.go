@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"github.com/anonyindian/gottbot"
+	"github.com/anonyindian/gottbot/ext"
+)
+
+// UpdateType handles the updates of the provided types.
+type UpdateType struct {
+	Response    Callback
+	UpdateTypes []gottbot.UpdateType
+	handlerID   string
+}
+
+func UpdateTypeHandler(updateTypes []gottbot.UpdateType, callback Callback) *UpdateType {
+	return &UpdateType{
+		Response:    callback,
+		UpdateTypes: updateTypes,
+		handlerID:   makeHandlerID("update_type"),
+	}
+}
+
+func (m *UpdateType) CheckUpdate(update *gottbot.Update) bool {
+	for _, updateType := range m.UpdateTypes {
+		if update.GetUpdateType() == updateType {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *UpdateType) GetUpdateTypes() []gottbot.UpdateType {
+	return m.UpdateTypes
+}
+
+func (m *UpdateType) HandleUpdate(bot *gottbot.Bot, ctx *ext.Context) error {
+	return m.Response(bot, ctx)
+}
+
+func (m *UpdateType) GetHandlerID() ext.HandlerID {
+	if m.handlerID == "" {
+		m.handlerID = makeHandlerID("update_type")
+	}
+	return ext.HandlerID(m.handlerID)
+}
+
+// SetName uses the provided name as the HandlerID of the handler.
+func (m *UpdateType) SetName(name string) *UpdateType {
+	m.handlerID = name
+	return m
+}
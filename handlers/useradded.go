@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"github.com/anonyindian/gottbot"
+	"github.com/anonyindian/gottbot/ext"
+	"github.com/anonyindian/gottbot/filters"
+)
+
+type UserAdded struct {
+	Response Callback
+	// Filter is optional, every update passes a nil filter.
+	Filter    filters.UserAddedFilter
+	handlerID string
+}
+
+func UserAddedHandler(filter filters.UserAddedFilter, callback Callback) *UserAdded {
+	return &UserAdded{
+		Response:  callback,
+		Filter:    filter,
+		handlerID: makeHandlerID("user_added"),
+	}
+}
+
+func (m *UserAdded) CheckUpdate(update *gottbot.Update) bool {
+	return update.UserAdded != nil
+}
+
+func (m *UserAdded) GetUpdateTypes() []gottbot.UpdateType {
+	return []gottbot.UpdateType{gottbot.UpdateTypeUserAdded}
+}
+
+func (m *UserAdded) HandleUpdate(bot *gottbot.Bot, ctx *ext.Context) error {
+	if m.Filter != nil && !m.Filter(ctx.UserAdded) {
+		return ext.ContinueGroup
+	}
+	return m.Response(bot, ctx)
+}
+
+func (m *UserAdded) GetHandlerID() ext.HandlerID {
+	if m.handlerID == "" {
+		m.handlerID = makeHandlerID("user_added")
+	}
+	return ext.HandlerID(m.handlerID)
+}
+
+// SetName uses the provided name as the HandlerID of the handler.
+func (m *UserAdded) SetName(name string) *UserAdded {
+	m.handlerID = name
+	return m
+}
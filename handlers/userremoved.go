@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"github.com/anonyindian/gottbot"
+	"github.com/anonyindian/gottbot/ext"
+	"github.com/anonyindian/gottbot/filters"
+)
+
+type UserRemoved struct {
+	Response Callback
+	// Filter is optional, every update passes a nil filter.
+	Filter    filters.UserRemovedFilter
+	handlerID string
+}
+
+func UserRemovedHandler(filter filters.UserRemovedFilter, callback Callback) *UserRemoved {
+	return &UserRemoved{
+		Response:  callback,
+		Filter:    filter,
+		handlerID: makeHandlerID("user_removed"),
+	}
+}
+
+func (m *UserRemoved) CheckUpdate(update *gottbot.Update) bool {
+	return update.UserRemoved != nil
+}
+
+func (m *UserRemoved) GetUpdateTypes() []gottbot.UpdateType {
+	return []gottbot.UpdateType{gottbot.UpdateTypeUserRemoved}
+}
+
+func (m *UserRemoved) HandleUpdate(bot *gottbot.Bot, ctx *ext.Context) error {
+	if m.Filter != nil && !m.Filter(ctx.UserRemoved) {
+		return ext.ContinueGroup
+	}
+	return m.Response(bot, ctx)
+}
+
+func (m *UserRemoved) GetHandlerID() ext.HandlerID {
+	if m.handlerID == "" {
+		m.handlerID = makeHandlerID("user_removed")
+	}
+	return ext.HandlerID(m.handlerID)
+}
+
+// SetName uses the provided name as the HandlerID of the handler.
+func (m *UserRemoved) SetName(name string) *UserRemoved {
+	m.handlerID = name
+	return m
+}
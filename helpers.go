@@ -2,6 +2,7 @@ package gottbot
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"os"
 	"time"
@@ -9,11 +10,21 @@ import (
 
 // Edit is a message helper to bot.EditMessage
 func (m *Message) Edit(bot *Bot, body NewMessageBody) (*SimpleQueryResult, error) {
-	return bot.EditMessage(m.Body.Mid, body)
+	return m.EditCtx(context.Background(), bot, body)
+}
+
+// EditCtx is the context-aware version of Edit.
+func (m *Message) EditCtx(ctx context.Context, bot *Bot, body NewMessageBody) (*SimpleQueryResult, error) {
+	return bot.EditMessageCtx(ctx, m.Body.Mid, body)
 }
 
 // Reply is a message helper to bot.SendMessage with reply message added
 func (m *Message) Reply(bot *Bot, text string, opts *SendMessageOpts) (*SendMessageResult, error) {
+	return m.ReplyCtx(context.Background(), bot, text, opts)
+}
+
+// ReplyCtx is the context-aware version of Reply.
+func (m *Message) ReplyCtx(ctx context.Context, bot *Bot, text string, opts *SendMessageOpts) (*SendMessageResult, error) {
 	if opts == nil {
 		opts = &SendMessageOpts{}
 	}
@@ -21,11 +32,16 @@ func (m *Message) Reply(bot *Bot, text string, opts *SendMessageOpts) (*SendMess
 		Mid:  m.Body.Mid,
 		Type: Reply,
 	}
-	return bot.SendMessage(m.Recipient.ChatId, text, opts)
+	return bot.SendMessageCtx(ctx, m.Recipient.ChatId, text, opts)
 }
 
 // Forward is a message helper to bot.SendMessage with forward message added
 func (m *Message) Forward(bot *Bot, chatId int64, text string, opts *SendMessageOpts) (*SendMessageResult, error) {
+	return m.ForwardCtx(context.Background(), bot, chatId, text, opts)
+}
+
+// ForwardCtx is the context-aware version of Forward.
+func (m *Message) ForwardCtx(ctx context.Context, bot *Bot, chatId int64, text string, opts *SendMessageOpts) (*SendMessageResult, error) {
 	if opts == nil {
 		opts = &SendMessageOpts{}
 	}
@@ -33,7 +49,7 @@ func (m *Message) Forward(bot *Bot, chatId int64, text string, opts *SendMessage
 		Mid:  m.Body.Mid,
 		Type: Forward,
 	}
-	return bot.SendMessage(chatId, text, opts)
+	return bot.SendMessageCtx(ctx, chatId, text, opts)
 }
 
 type InputFile interface {
@@ -61,6 +77,11 @@ type MediaOpts struct {
 }
 
 func SendFile[input InputFile](bot *Bot, chatId int64, file input, opts *MediaOpts) (*SendMessageResult, error) {
+	return SendFileCtx(context.Background(), bot, chatId, file, opts)
+}
+
+// SendFileCtx is the context-aware version of SendFile.
+func SendFileCtx[input InputFile](ctx context.Context, bot *Bot, chatId int64, file input, opts *MediaOpts) (*SendMessageResult, error) {
 	if opts == nil {
 		opts = new(MediaOpts)
 	}
@@ -82,7 +103,7 @@ func SendFile[input InputFile](bot *Bot, chatId int64, file input, opts *MediaOp
 				}},
 			}
 		}
-		return bot.SendMessage(chatId, opts.Text, &SendMessageOpts{
+		return bot.SendMessageCtx(ctx, chatId, opts.Text, &SendMessageOpts{
 			opts.DisableLinkPreview,
 			atts,
 			opts.Link,
@@ -100,7 +121,7 @@ func SendFile[input InputFile](bot *Bot, chatId int64, file input, opts *MediaOp
 
 	wait := initUploadWait(fileInfo.File)
 
-	payload, err := bot.Upload(UploadTypeFile, fileInfo)
+	payload, err := bot.UploadCtx(ctx, UploadTypeFile, fileInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -119,7 +140,7 @@ func SendFile[input InputFile](bot *Bot, chatId int64, file input, opts *MediaOp
 			{opts.Payload},
 		}
 	}
-	return bot.SendMessage(chatId, opts.Text, &SendMessageOpts{
+	return bot.SendMessageCtx(ctx, chatId, opts.Text, &SendMessageOpts{
 		opts.DisableLinkPreview,
 		atts,
 		opts.Link,
@@ -129,6 +150,11 @@ func SendFile[input InputFile](bot *Bot, chatId int64, file input, opts *MediaOp
 }
 
 func SendVideo[input InputFile](bot *Bot, chatId int64, video input, opts *MediaOpts) (*SendMessageResult, error) {
+	return SendVideoCtx(context.Background(), bot, chatId, video, opts)
+}
+
+// SendVideoCtx is the context-aware version of SendVideo.
+func SendVideoCtx[input InputFile](ctx context.Context, bot *Bot, chatId int64, video input, opts *MediaOpts) (*SendMessageResult, error) {
 	if opts == nil {
 		opts = new(MediaOpts)
 	}
@@ -150,7 +176,7 @@ func SendVideo[input InputFile](bot *Bot, chatId int64, video input, opts *Media
 				}},
 			}
 		}
-		return bot.SendMessage(chatId, opts.Text, &SendMessageOpts{
+		return bot.SendMessageCtx(ctx, chatId, opts.Text, &SendMessageOpts{
 			opts.DisableLinkPreview,
 			atts,
 			opts.Link,
@@ -168,7 +194,7 @@ func SendVideo[input InputFile](bot *Bot, chatId int64, video input, opts *Media
 
 	wait := initUploadWait(fileInfo.File)
 
-	payload, err := bot.Upload(UploadTypeVideo, fileInfo)
+	payload, err := bot.UploadCtx(ctx, UploadTypeVideo, fileInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -187,7 +213,7 @@ func SendVideo[input InputFile](bot *Bot, chatId int64, video input, opts *Media
 			{opts.Payload},
 		}
 	}
-	return bot.SendMessage(chatId, opts.Text, &SendMessageOpts{
+	return bot.SendMessageCtx(ctx, chatId, opts.Text, &SendMessageOpts{
 		opts.DisableLinkPreview,
 		atts,
 		opts.Link,
@@ -197,6 +223,11 @@ func SendVideo[input InputFile](bot *Bot, chatId int64, video input, opts *Media
 }
 
 func SendAudio[input InputFile](bot *Bot, chatId int64, audio input, opts *MediaOpts) (*SendMessageResult, error) {
+	return SendAudioCtx(context.Background(), bot, chatId, audio, opts)
+}
+
+// SendAudioCtx is the context-aware version of SendAudio.
+func SendAudioCtx[input InputFile](ctx context.Context, bot *Bot, chatId int64, audio input, opts *MediaOpts) (*SendMessageResult, error) {
 	if opts == nil {
 		opts = new(MediaOpts)
 	}
@@ -218,7 +249,7 @@ func SendAudio[input InputFile](bot *Bot, chatId int64, audio input, opts *Media
 				}},
 			}
 		}
-		return bot.SendMessage(chatId, opts.Text, &SendMessageOpts{
+		return bot.SendMessageCtx(ctx, chatId, opts.Text, &SendMessageOpts{
 			opts.DisableLinkPreview,
 			atts,
 			opts.Link,
@@ -236,7 +267,7 @@ func SendAudio[input InputFile](bot *Bot, chatId int64, audio input, opts *Media
 
 	wait := initUploadWait(fileInfo.File)
 
-	payload, err := bot.Upload(UploadTypeAudio, fileInfo)
+	payload, err := bot.UploadCtx(ctx, UploadTypeAudio, fileInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -255,7 +286,7 @@ func SendAudio[input InputFile](bot *Bot, chatId int64, audio input, opts *Media
 			{opts.Payload},
 		}
 	}
-	return bot.SendMessage(chatId, opts.Text, &SendMessageOpts{
+	return bot.SendMessageCtx(ctx, chatId, opts.Text, &SendMessageOpts{
 		opts.DisableLinkPreview,
 		atts,
 		opts.Link,
@@ -265,6 +296,11 @@ func SendAudio[input InputFile](bot *Bot, chatId int64, audio input, opts *Media
 }
 
 func SendPhoto[input InputFile](bot *Bot, chatId int64, photo input, opts *MediaOpts) (*SendMessageResult, error) {
+	return SendPhotoCtx(context.Background(), bot, chatId, photo, opts)
+}
+
+// SendPhotoCtx is the context-aware version of SendPhoto.
+func SendPhotoCtx[input InputFile](ctx context.Context, bot *Bot, chatId int64, photo input, opts *MediaOpts) (*SendMessageResult, error) {
 	if opts == nil {
 		opts = new(MediaOpts)
 	}
@@ -286,7 +322,7 @@ func SendPhoto[input InputFile](bot *Bot, chatId int64, photo input, opts *Media
 				}},
 			}
 		}
-		return bot.SendMessage(chatId, opts.Text, &SendMessageOpts{
+		return bot.SendMessageCtx(ctx, chatId, opts.Text, &SendMessageOpts{
 			opts.DisableLinkPreview,
 			atts,
 			opts.Link,
@@ -304,7 +340,7 @@ func SendPhoto[input InputFile](bot *Bot, chatId int64, photo input, opts *Media
 
 	wait := initUploadWait(fileInfo.File)
 
-	payload, err := bot.Upload(UploadTypeImage, fileInfo)
+	payload, err := bot.UploadCtx(ctx, UploadTypeImage, fileInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -323,7 +359,7 @@ func SendPhoto[input InputFile](bot *Bot, chatId int64, photo input, opts *Media
 			{opts.Payload},
 		}
 	}
-	return bot.SendMessage(chatId, opts.Text, &SendMessageOpts{
+	return bot.SendMessageCtx(ctx, chatId, opts.Text, &SendMessageOpts{
 		opts.DisableLinkPreview,
 		atts,
 		opts.Link,
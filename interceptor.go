@@ -0,0 +1,94 @@
+package gottbot
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// APICall describes a call to the Bot API going through the interceptors of a Bot.
+type APICall struct {
+	// Method Bot API method, e.g. "messages", or "upload" for the file uploads of Bot.Upload
+	Method string
+
+	// HTTPMethod HTTP verb of the request
+	HTTPMethod string
+
+	// URL of the request, without the access token
+	URL string
+
+	// Header Headers sent with the request
+	Header http.Header
+
+	// Params Query parameters of the request, without the access token
+	Params url.Values
+
+	// Body Request body, can be nil
+	Body []byte
+
+	// Result The response is decoded into it, nil for the raw calls of MakeRequest
+	Result any
+
+	// Response Raw body of the successful response, set once the call has been executed
+	Response []byte
+
+	// upload calls go to the upload servers, so they carry no access token and no default timeout
+	upload bool
+}
+
+// Invoker executes an APICall, decoding the response into call.Result.
+type Invoker func(ctx context.Context, call *APICall) error
+
+// Interceptor observes or modifies an APICall, it must call next to execute the call
+// unless it wants to short-circuit it, in which case it may fill call.Result itself.
+type Interceptor func(ctx context.Context, call *APICall, next Invoker) error
+
+// Use appends the interceptors to the chain every API call of the bot goes through.
+// Interceptors run in the order they were added, the first one being the outermost.
+func (b *Bot) Use(interceptors ...Interceptor) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	// copied on write since invoke may be iterating the old slice
+	newInterceptors := make([]Interceptor, 0, len(b.interceptors)+len(interceptors))
+	newInterceptors = append(newInterceptors, b.interceptors...)
+	b.interceptors = append(newInterceptors, interceptors...)
+}
+
+// invoke runs the call through the interceptors and executes it.
+func (b *Bot) invoke(ctx context.Context, call *APICall) error {
+	b.mu.RLock()
+	interceptors := b.interceptors
+	b.mu.RUnlock()
+	invoker := Invoker(b.execute)
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], invoker
+		invoker = func(ctx context.Context, call *APICall) error {
+			return interceptor(ctx, call, next)
+		}
+	}
+	return invoker(ctx, call)
+}
+
+// callMethod calls the Bot API method and decodes its response into result.
+func (b *Bot) callMethod(ctx context.Context, httpMethod string, method string, params url.Values, body []byte, result any) error {
+	return b.invoke(ctx, b.newAPICall(httpMethod, method, params, body, result))
+}
+
+func (b *Bot) newAPICall(httpMethod string, method string, params url.Values, body []byte, result any) *APICall {
+	header := b.headers.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	if b.userAgent != "" {
+		header.Set("User-Agent", b.userAgent)
+	}
+	return &APICall{
+		Method:     method,
+		HTTPMethod: httpMethod,
+		URL:        b.apiUrl + "/" + method,
+		Header:     header,
+		Params:     params,
+		Body:       body,
+		Result:     result,
+	}
+}
@@ -0,0 +1,62 @@
+package gottbot
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Logger is used by the library to report what it does.
+// args are alternating keys and values, the method set is compatible with *slog.Logger.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+var (
+	// DefaultLogger writes the warnings and errors with the standard logger of the log package.
+	DefaultLogger Logger = stdLogger{}
+	// NopLogger discards everything.
+	NopLogger Logger = nopLogger{}
+)
+
+type stdLogger struct{}
+
+func (stdLogger) Debug(string, ...any) {}
+
+func (stdLogger) Info(string, ...any) {}
+
+func (stdLogger) Warn(msg string, args ...any) {
+	log.Print(formatLog("WARN", msg, args))
+}
+
+func (stdLogger) Error(msg string, args ...any) {
+	log.Print(formatLog("ERROR", msg, args))
+}
+
+func formatLog(level, msg string, args []any) string {
+	var sb strings.Builder
+	sb.WriteString(level)
+	sb.WriteString(" ")
+	sb.WriteString(msg)
+	for i := 0; i < len(args); i += 2 {
+		if i+1 < len(args) {
+			fmt.Fprintf(&sb, " %v=%v", args[i], args[i+1])
+		} else {
+			fmt.Fprintf(&sb, " !BADKEY=%v", args[i])
+		}
+	}
+	return sb.String()
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...any) {}
+
+func (nopLogger) Info(string, ...any) {}
+
+func (nopLogger) Warn(string, ...any) {}
+
+func (nopLogger) Error(string, ...any) {}
@@ -2,6 +2,7 @@ package gottbot
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,44 +10,52 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 )
 
 // Returns info about current bot.
 // Current bot can be identified by access token.
 // Method returns bot identifier, name and avatar (if any)
 func (b *Bot) GetInfo() (*BotInfo, error) {
-	data, err := b.MakeRequest(http.MethodGet, "me", url.Values{}, nil)
-	if data != nil {
-		defer data.Close()
-	}
-	if err != nil {
+	return b.GetInfoCtx(context.Background())
+}
+
+// GetInfoCtx is the context-aware version of GetInfo.
+func (b *Bot) GetInfoCtx(ctx context.Context) (*BotInfo, error) {
+	var v BotInfo
+	if err := b.callMethod(ctx, http.MethodGet, "me", url.Values{}, nil, &v); err != nil {
 		return nil, err
 	}
-	var v BotInfo
-	return &v, json.NewDecoder(data).Decode(&v)
+	return &v, nil
 }
 
 // Edits current bot info. Fill only the fields you want to update.
 // All remaining fields will stay untouched
 func (b *Bot) PatchInfo(patch BotPatch) (*BotInfo, error) {
+	return b.PatchInfoCtx(context.Background(), patch)
+}
+
+// PatchInfoCtx is the context-aware version of PatchInfo.
+func (b *Bot) PatchInfoCtx(ctx context.Context, patch BotPatch) (*BotInfo, error) {
 	bs, err := json.Marshal(patch)
 	if err != nil {
 		return nil, fmt.Errorf("failed to encode patch: %w", err)
 	}
-	data, err := b.MakeRequest(http.MethodPatch, "me", url.Values{}, bs)
-	if data != nil {
-		defer data.Close()
-	}
-	if err != nil {
+	var v BotInfo
+	if err := b.callMethod(ctx, http.MethodPatch, "me", url.Values{}, bs, &v); err != nil {
 		return nil, err
 	}
-	var v BotInfo
-	return &v, json.NewDecoder(data).Decode(&v)
+	return &v, nil
 }
 
 // Returns information about chats that bot participated in:
 // a result list and marker points to the next page
 func (b *Bot) GetChats(opts *GetChatsOpts) (*ChatList, error) {
+	return b.GetChatsCtx(context.Background(), opts)
+}
+
+// GetChatsCtx is the context-aware version of GetChats.
+func (b *Bot) GetChatsCtx(ctx context.Context, opts *GetChatsOpts) (*ChatList, error) {
 	if opts == nil {
 		opts = &GetChatsOpts{}
 	}
@@ -57,157 +66,168 @@ func (b *Bot) GetChats(opts *GetChatsOpts) (*ChatList, error) {
 	if opts.Marker != 0 {
 		u.Add("marker", strconv.FormatInt(opts.Marker, 10))
 	}
-	data, err := b.MakeRequest(http.MethodGet, "chats", u, nil)
-	if data != nil {
-		defer data.Close()
-	}
-	if err != nil {
+	var v ChatList
+	if err := b.callMethod(ctx, http.MethodGet, "chats", u, nil, &v); err != nil {
 		return nil, err
 	}
-	var v ChatList
-	return &v, json.NewDecoder(data).Decode(&v)
+	return &v, nil
 }
 
 // Returns chat/channel information by its public link or dialog with user by username
 func (b *Bot) GetChatByLink(link string) (*Chat, error) {
-	data, err := b.MakeRequest(http.MethodGet, fmt.Sprintf("chats/%s", link), url.Values{}, nil)
-	if data != nil {
-		defer data.Close()
-	}
-	if err != nil {
+	return b.GetChatByLinkCtx(context.Background(), link)
+}
+
+// GetChatByLinkCtx is the context-aware version of GetChatByLink.
+func (b *Bot) GetChatByLinkCtx(ctx context.Context, link string) (*Chat, error) {
+	var v Chat
+	if err := b.callMethod(ctx, http.MethodGet, fmt.Sprintf("chats/%s", link), url.Values{}, nil, &v); err != nil {
 		return nil, err
 	}
-	var v Chat
-	return &v, json.NewDecoder(data).Decode(&v)
+	return &v, nil
 }
 
 // Returns info about chat.
 func (b *Bot) GetChat(chatId int64) (*Chat, error) {
-	data, err := b.MakeRequest(http.MethodGet, fmt.Sprintf("chats/%d", chatId), url.Values{}, nil)
-	if data != nil {
-		defer data.Close()
-	}
-	if err != nil {
+	return b.GetChatCtx(context.Background(), chatId)
+}
+
+// GetChatCtx is the context-aware version of GetChat.
+func (b *Bot) GetChatCtx(ctx context.Context, chatId int64) (*Chat, error) {
+	var v Chat
+	if err := b.callMethod(ctx, http.MethodGet, fmt.Sprintf("chats/%d", chatId), url.Values{}, nil, &v); err != nil {
 		return nil, err
 	}
-	var v Chat
-	return &v, json.NewDecoder(data).Decode(&v)
+	return &v, nil
 }
 
 // Edits chat info: title, icon, etc…
 func (b *Bot) EditChat(chatId int64, patch ChatPatch) (*Chat, error) {
+	return b.EditChatCtx(context.Background(), chatId, patch)
+}
+
+// EditChatCtx is the context-aware version of EditChat.
+func (b *Bot) EditChatCtx(ctx context.Context, chatId int64, patch ChatPatch) (*Chat, error) {
 	bs, err := json.Marshal(patch)
 	if err != nil {
 		return nil, fmt.Errorf("failed to encode patch: %w", err)
 	}
-	data, err := b.MakeRequest(http.MethodPatch, fmt.Sprintf("chats/%d", chatId), url.Values{}, bs)
-	if data != nil {
-		defer data.Close()
-	}
-	if err != nil {
+	var v Chat
+	if err := b.callMethod(ctx, http.MethodPatch, fmt.Sprintf("chats/%d", chatId), url.Values{}, bs, &v); err != nil {
 		return nil, err
 	}
-	var v Chat
-	return &v, json.NewDecoder(data).Decode(&v)
+	return &v, nil
 }
 
 // Send bot action to chat.
 func (b *Bot) SendAction(chatId int64, action SenderAction) (*SimpleQueryResult, error) {
-	data, err := b.MakeRequest(http.MethodPost, fmt.Sprintf("chats/%d/actions", chatId), url.Values{}, []byte(action))
-	if data != nil {
-		defer data.Close()
-	}
-	if err != nil {
+	return b.SendActionCtx(context.Background(), chatId, action)
+}
+
+// SendActionCtx is the context-aware version of SendAction.
+func (b *Bot) SendActionCtx(ctx context.Context, chatId int64, action SenderAction) (*SimpleQueryResult, error) {
+	var v SimpleQueryResult
+	if err := b.callMethod(ctx, http.MethodPost, fmt.Sprintf("chats/%d/actions", chatId), url.Values{}, []byte(action), &v); err != nil {
 		return nil, err
 	}
-	var v SimpleQueryResult
-	return &v, json.NewDecoder(data).Decode(&v)
+	return &v, nil
 }
 
 // Get pinned message in chat or channel.
 func (b *Bot) GetPinnedMessage(chatId int64) (*GetPinnedMessageResult, error) {
-	data, err := b.MakeRequest(http.MethodGet, fmt.Sprintf("chats/%d/pin", chatId), url.Values{}, nil)
-	if data != nil {
-		defer data.Close()
-	}
-	if err != nil {
+	return b.GetPinnedMessageCtx(context.Background(), chatId)
+}
+
+// GetPinnedMessageCtx is the context-aware version of GetPinnedMessage.
+func (b *Bot) GetPinnedMessageCtx(ctx context.Context, chatId int64) (*GetPinnedMessageResult, error) {
+	var v GetPinnedMessageResult
+	if err := b.callMethod(ctx, http.MethodGet, fmt.Sprintf("chats/%d/pin", chatId), url.Values{}, nil, &v); err != nil {
 		return nil, err
 	}
-	var v GetPinnedMessageResult
-	return &v, json.NewDecoder(data).Decode(&v)
+	return &v, nil
 }
 
 // Pins message in chat or channel.
 func (b *Bot) PinMessage(chatId int64, body PinMessageBody) (*SimpleQueryResult, error) {
+	return b.PinMessageCtx(context.Background(), chatId, body)
+}
+
+// PinMessageCtx is the context-aware version of PinMessage.
+func (b *Bot) PinMessageCtx(ctx context.Context, chatId int64, body PinMessageBody) (*SimpleQueryResult, error) {
 	bs, err := json.Marshal(body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to encode body: %w", err)
 	}
-	data, err := b.MakeRequest(http.MethodPut, fmt.Sprintf("chats/%d/pin", chatId), url.Values{}, bs)
-	if data != nil {
-		defer data.Close()
-	}
-	if err != nil {
+	var v SimpleQueryResult
+	if err := b.callMethod(ctx, http.MethodPut, fmt.Sprintf("chats/%d/pin", chatId), url.Values{}, bs, &v); err != nil {
 		return nil, err
 	}
-	var v SimpleQueryResult
-	return &v, json.NewDecoder(data).Decode(&v)
+	return &v, nil
 }
 
 // Unpins message in chat or channel.
 func (b *Bot) UnpinMessage(chatId int64) (*SimpleQueryResult, error) {
-	data, err := b.MakeRequest(http.MethodDelete, fmt.Sprintf("chats/%d/pin", chatId), url.Values{}, nil)
-	if data != nil {
-		defer data.Close()
-	}
-	if err != nil {
+	return b.UnpinMessageCtx(context.Background(), chatId)
+}
+
+// UnpinMessageCtx is the context-aware version of UnpinMessage.
+func (b *Bot) UnpinMessageCtx(ctx context.Context, chatId int64) (*SimpleQueryResult, error) {
+	var v SimpleQueryResult
+	if err := b.callMethod(ctx, http.MethodDelete, fmt.Sprintf("chats/%d/pin", chatId), url.Values{}, nil, &v); err != nil {
 		return nil, err
 	}
-	var v SimpleQueryResult
-	return &v, json.NewDecoder(data).Decode(&v)
+	return &v, nil
 }
 
 // Returns chat membership info for current bot
 func (b *Bot) GetChatMembership(chatId int64) (*ChatMember, error) {
-	data, err := b.MakeRequest(http.MethodGet, fmt.Sprintf("chats/%d/members/me", chatId), url.Values{}, nil)
-	if data != nil {
-		defer data.Close()
-	}
-	if err != nil {
+	return b.GetChatMembershipCtx(context.Background(), chatId)
+}
+
+// GetChatMembershipCtx is the context-aware version of GetChatMembership.
+func (b *Bot) GetChatMembershipCtx(ctx context.Context, chatId int64) (*ChatMember, error) {
+	var v ChatMember
+	if err := b.callMethod(ctx, http.MethodGet, fmt.Sprintf("chats/%d/members/me", chatId), url.Values{}, nil, &v); err != nil {
 		return nil, err
 	}
-	var v ChatMember
-	return &v, json.NewDecoder(data).Decode(&v)
+	return &v, nil
 }
 
 // Removes bot from chat members.
 func (b *Bot) LeaveChat(chatId int64) (*SimpleQueryResult, error) {
-	data, err := b.MakeRequest(http.MethodDelete, fmt.Sprintf("chats/%d/members/me", chatId), url.Values{}, nil)
-	if data != nil {
-		defer data.Close()
-	}
-	if err != nil {
+	return b.LeaveChatCtx(context.Background(), chatId)
+}
+
+// LeaveChatCtx is the context-aware version of LeaveChat.
+func (b *Bot) LeaveChatCtx(ctx context.Context, chatId int64) (*SimpleQueryResult, error) {
+	var v SimpleQueryResult
+	if err := b.callMethod(ctx, http.MethodDelete, fmt.Sprintf("chats/%d/members/me", chatId), url.Values{}, nil, &v); err != nil {
 		return nil, err
 	}
-	var v SimpleQueryResult
-	return &v, json.NewDecoder(data).Decode(&v)
+	return &v, nil
 }
 
 // Returns all chat administrators. Bot must be administrator in requested chat.
 func (b *Bot) GetChatAdmins(chatId int64) (*ChatMembersList, error) {
-	data, err := b.MakeRequest(http.MethodGet, fmt.Sprintf("chats/%d/members/admins", chatId), url.Values{}, nil)
-	if data != nil {
-		defer data.Close()
-	}
-	if err != nil {
+	return b.GetChatAdminsCtx(context.Background(), chatId)
+}
+
+// GetChatAdminsCtx is the context-aware version of GetChatAdmins.
+func (b *Bot) GetChatAdminsCtx(ctx context.Context, chatId int64) (*ChatMembersList, error) {
+	var v ChatMembersList
+	if err := b.callMethod(ctx, http.MethodGet, fmt.Sprintf("chats/%d/members/admins", chatId), url.Values{}, nil, &v); err != nil {
 		return nil, err
 	}
-	var v ChatMembersList
-	return &v, json.NewDecoder(data).Decode(&v)
+	return &v, nil
 }
 
 // Returns users participated in chat.
 func (b *Bot) GetChatMembers(chatId int64, opts *GetChatMembersOpts) (*ChatMembersList, error) {
+	return b.GetChatMembersCtx(context.Background(), chatId, opts)
+}
+
+// GetChatMembersCtx is the context-aware version of GetChatMembers.
+func (b *Bot) GetChatMembersCtx(ctx context.Context, chatId int64, opts *GetChatMembersOpts) (*ChatMembersList, error) {
 	if opts == nil {
 		opts = &GetChatMembersOpts{}
 	}
@@ -225,48 +245,46 @@ func (b *Bot) GetChatMembers(chatId int64, opts *GetChatMembersOpts) (*ChatMembe
 	if opts.Marker != 0 {
 		u.Add("marker", strconv.FormatInt(opts.Marker, 10))
 	}
-	data, err := b.MakeRequest(http.MethodGet, fmt.Sprintf("chats/%d/members", chatId), u, nil)
-	if data != nil {
-		defer data.Close()
-	}
-	if err != nil {
+	var v ChatMembersList
+	if err := b.callMethod(ctx, http.MethodGet, fmt.Sprintf("chats/%d/members", chatId), u, nil, &v); err != nil {
 		return nil, err
 	}
-	var v ChatMembersList
-	return &v, json.NewDecoder(data).Decode(&v)
+	return &v, nil
 }
 
 // Adds members to chat. Additional permissions may require.
 func (b *Bot) AddMembers(chatId int64, userIds []int64) (*SimpleQueryResult, error) {
+	return b.AddMembersCtx(context.Background(), chatId, userIds)
+}
+
+// AddMembersCtx is the context-aware version of AddMembers.
+func (b *Bot) AddMembersCtx(ctx context.Context, chatId int64, userIds []int64) (*SimpleQueryResult, error) {
 	bs, err := json.Marshal(userIds)
 	if err != nil {
 		return nil, fmt.Errorf("failed to encode userIds: %w", err)
 	}
-	data, err := b.MakeRequest(http.MethodPost, fmt.Sprintf("chats/%d/members", chatId), url.Values{}, bs)
-	if data != nil {
-		defer data.Close()
-	}
-	if err != nil {
+	var v SimpleQueryResult
+	if err := b.callMethod(ctx, http.MethodPost, fmt.Sprintf("chats/%d/members", chatId), url.Values{}, bs, &v); err != nil {
 		return nil, err
 	}
-	var v SimpleQueryResult
-	return &v, json.NewDecoder(data).Decode(&v)
+	return &v, nil
 }
 
 // Removes member from chat. Additional permissions may require.
 func (b *Bot) RemoveMember(chatId int64, userId int64, block bool) (*SimpleQueryResult, error) {
+	return b.RemoveMemberCtx(context.Background(), chatId, userId, block)
+}
+
+// RemoveMemberCtx is the context-aware version of RemoveMember.
+func (b *Bot) RemoveMemberCtx(ctx context.Context, chatId int64, userId int64, block bool) (*SimpleQueryResult, error) {
 	u := url.Values{}
 	u.Add("user_id", strconv.FormatInt(userId, 10))
 	u.Add("block", strconv.FormatBool(block))
-	data, err := b.MakeRequest(http.MethodDelete, fmt.Sprintf("chats/%d/members", chatId), u, nil)
-	if data != nil {
-		defer data.Close()
-	}
-	if err != nil {
+	var v SimpleQueryResult
+	if err := b.callMethod(ctx, http.MethodDelete, fmt.Sprintf("chats/%d/members", chatId), u, nil, &v); err != nil {
 		return nil, err
 	}
-	var v SimpleQueryResult
-	return &v, json.NewDecoder(data).Decode(&v)
+	return &v, nil
 }
 
 // Returns messages in chat: result page and marker referencing to the next page.
@@ -274,6 +292,11 @@ func (b *Bot) RemoveMember(chatId int64, userId int64, block bool) (*SimpleQuery
 // Messages traversed in reverse direction so the latest message in chat will be first in result array.
 // Therefore if you use from and to parameters, to must be less than from
 func (b *Bot) GetMessages(opts *GetMessagesOpts) (*MessageList, error) {
+	return b.GetMessagesCtx(context.Background(), opts)
+}
+
+// GetMessagesCtx is the context-aware version of GetMessages.
+func (b *Bot) GetMessagesCtx(ctx context.Context, opts *GetMessagesOpts) (*MessageList, error) {
 	if opts == nil {
 		opts = &GetMessagesOpts{}
 	}
@@ -297,15 +320,11 @@ func (b *Bot) GetMessages(opts *GetMessagesOpts) (*MessageList, error) {
 	if opts.To != 0 {
 		u.Add("to", strconv.FormatInt(opts.To, 10))
 	}
-	data, err := b.MakeRequest(http.MethodGet, "messages", u, nil)
-	if data != nil {
-		defer data.Close()
-	}
-	if err != nil {
+	var v MessageList
+	if err := b.callMethod(ctx, http.MethodGet, "messages", u, nil, &v); err != nil {
 		return nil, err
 	}
-	var v MessageList
-	return &v, json.NewDecoder(data).Decode(&v)
+	return &v, nil
 }
 
 // Sends a message to a chat. As a result for this method new message identifier returns.
@@ -316,6 +335,11 @@ func (b *Bot) GetMessages(opts *GetMessagesOpts) (*MessageList, error) {
 // It means the last step will fail with 400 error.
 // Try to send a message again until you'll get a successful result.
 func (b *Bot) SendMessage(chatId int64, text string, opts *SendMessageOpts) (*SendMessageResult, error) {
+	return b.SendMessageCtx(context.Background(), chatId, text, opts)
+}
+
+// SendMessageCtx is the context-aware version of SendMessage.
+func (b *Bot) SendMessageCtx(ctx context.Context, chatId int64, text string, opts *SendMessageOpts) (*SendMessageResult, error) {
 	if opts == nil {
 		opts = &SendMessageOpts{}
 	}
@@ -341,104 +365,105 @@ func (b *Bot) SendMessage(chatId int64, text string, opts *SendMessageOpts) (*Se
 		return nil, fmt.Errorf("failed to encode SendMessageBody: %w", err)
 	}
 
-	data, err := b.MakeRequest(http.MethodPost, "messages", u, bs)
-	if data != nil {
-		defer data.Close()
-	}
-	if err != nil {
+	var v SendMessageResult
+	if err := b.callMethod(ctx, http.MethodPost, "messages", u, bs, &v); err != nil {
 		return nil, err
 	}
-	var v SendMessageResult
-	return &v, json.NewDecoder(data).Decode(&v)
+	return &v, nil
 }
 
 // Updated message should be sent as NewMessageBody in a request body.
 // In case attachments field is null, the current message attachments won’t be changed.
 // In case of sending an empty list in this field, all attachments will be deleted.
 func (b *Bot) EditMessage(messageId string, body NewMessageBody) (*SimpleQueryResult, error) {
+	return b.EditMessageCtx(context.Background(), messageId, body)
+}
+
+// EditMessageCtx is the context-aware version of EditMessage.
+func (b *Bot) EditMessageCtx(ctx context.Context, messageId string, body NewMessageBody) (*SimpleQueryResult, error) {
 	u := url.Values{}
 	u.Add("message_id", messageId)
 	bs, err := json.Marshal(body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to encode NewMessageBody: %w", err)
 	}
-	data, err := b.MakeRequest(http.MethodPut, "messages", u, bs)
-	if data != nil {
-		defer data.Close()
-	}
-	if err != nil {
+	var v SimpleQueryResult
+	if err := b.callMethod(ctx, http.MethodPut, "messages", u, bs, &v); err != nil {
 		return nil, err
 	}
-	var v SimpleQueryResult
-	return &v, json.NewDecoder(data).Decode(&v)
+	return &v, nil
 }
 
 // Deletes message in a dialog or in a chat if bot has permission to delete messages.
 func (b *Bot) DeleteMessage(messageId string) (*SimpleQueryResult, error) {
+	return b.DeleteMessageCtx(context.Background(), messageId)
+}
+
+// DeleteMessageCtx is the context-aware version of DeleteMessage.
+func (b *Bot) DeleteMessageCtx(ctx context.Context, messageId string) (*SimpleQueryResult, error) {
 	u := url.Values{}
 	u.Add("message_id", messageId)
-	data, err := b.MakeRequest(http.MethodDelete, "messages", u, nil)
-	if data != nil {
-		defer data.Close()
-	}
-	if err != nil {
+	var v SimpleQueryResult
+	if err := b.callMethod(ctx, http.MethodDelete, "messages", u, nil, &v); err != nil {
 		return nil, err
 	}
-	var v SimpleQueryResult
-	return &v, json.NewDecoder(data).Decode(&v)
+	return &v, nil
 }
 
 // Returns single message by its identifier.
 func (b *Bot) GetMessage(messageId string) (*Message, error) {
-	data, err := b.MakeRequest(http.MethodGet, fmt.Sprintf("messages/%s", messageId), url.Values{}, nil)
-	if data != nil {
-		defer data.Close()
-	}
-	if err != nil {
+	return b.GetMessageCtx(context.Background(), messageId)
+}
+
+// GetMessageCtx is the context-aware version of GetMessage.
+func (b *Bot) GetMessageCtx(ctx context.Context, messageId string) (*Message, error) {
+	var v Message
+	if err := b.callMethod(ctx, http.MethodGet, fmt.Sprintf("messages/%s", messageId), url.Values{}, nil, &v); err != nil {
 		return nil, err
 	}
-	var v Message
-	return &v, json.NewDecoder(data).Decode(&v)
+	return &v, nil
 }
 
 // This method should be called to send an answer after a user has clicked the button.
 // The answer may be an updated message or/and a one-time user notification.
 func (b *Bot) AnswerOnCallback(callbackId string, body CallbackAnswer) (*SimpleQueryResult, error) {
+	return b.AnswerOnCallbackCtx(context.Background(), callbackId, body)
+}
+
+// AnswerOnCallbackCtx is the context-aware version of AnswerOnCallback.
+func (b *Bot) AnswerOnCallbackCtx(ctx context.Context, callbackId string, body CallbackAnswer) (*SimpleQueryResult, error) {
 	u := url.Values{}
 	u.Add("callback_id", callbackId)
 	bs, err := json.Marshal(body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to encode CallbackAnswer: %w", err)
 	}
-	data, err := b.MakeRequest(http.MethodPost, "answers", u, bs)
-	if data != nil {
-		defer data.Close()
-	}
-	if err != nil {
+	var v SimpleQueryResult
+	if err := b.callMethod(ctx, http.MethodPost, "answers", u, bs, &v); err != nil {
 		return nil, err
 	}
-	var v SimpleQueryResult
-	return &v, json.NewDecoder(data).Decode(&v)
+	return &v, nil
 }
 
 // Sends answer on construction request.
 // Answer can contain any prepared message and/or keyboard to help user interact with bot.
 func (b *Bot) ConstructMessage(sessionId string, body ConstructorAnswer) (*SimpleQueryResult, error) {
+	return b.ConstructMessageCtx(context.Background(), sessionId, body)
+}
+
+// ConstructMessageCtx is the context-aware version of ConstructMessage.
+func (b *Bot) ConstructMessageCtx(ctx context.Context, sessionId string, body ConstructorAnswer) (*SimpleQueryResult, error) {
 	u := url.Values{}
 	u.Add("session_id", sessionId)
 	bs, err := json.Marshal(body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to encode ConstructorAnswer: %w", err)
 	}
-	data, err := b.MakeRequest(http.MethodPost, "answers/constructor", u, bs)
-	if data != nil {
-		defer data.Close()
-	}
-	if err != nil {
+	var v SimpleQueryResult
+	if err := b.callMethod(ctx, http.MethodPost, "answers/constructor", u, bs, &v); err != nil {
 		return nil, err
 	}
-	var v SimpleQueryResult
-	return &v, json.NewDecoder(data).Decode(&v)
+	return &v, nil
 }
 
 // You can use this method for getting updates in case your bot is not subscribed to WebHook.
@@ -449,6 +474,15 @@ func (b *Bot) ConstructMessage(sessionId string, body ConstructorAnswer) (*Simpl
 // All previous updates are considered as committed after passing marker parameter.
 // If marker parameter is not passed, your bot will get all updates happened after the last commitment.
 func (b *Bot) GetUpdates(opts *GetUpdatesOpts) (*UpdateList, error) {
+	return b.GetUpdatesCtx(context.Background(), opts)
+}
+
+// GetUpdatesCtx is the context-aware version of GetUpdates.
+//
+// If ctx has no deadline, the request is allowed to last for the long polling timeout
+// (POLL_TIMEOUT if not set in opts) plus the timeout of the bot.
+func (b *Bot) GetUpdatesCtx(ctx context.Context, opts *GetUpdatesOpts) (*UpdateList, error) {
+	pollTimeout := time.Second * POLL_TIMEOUT
 	u := url.Values{}
 	if opts != nil {
 		if opts.Limit != 0 {
@@ -459,6 +493,7 @@ func (b *Bot) GetUpdates(opts *GetUpdatesOpts) (*UpdateList, error) {
 		}
 		if opts.Timeout != 0 {
 			u.Add("timeout", strconv.FormatInt(int64(opts.Timeout), 10))
+			pollTimeout = time.Second * time.Duration(opts.Timeout)
 		}
 		if opts.Types != nil {
 			bs, err := json.Marshal(opts.Types)
@@ -468,78 +503,78 @@ func (b *Bot) GetUpdates(opts *GetUpdatesOpts) (*UpdateList, error) {
 			u.Add("types", string(bs))
 		}
 	}
-	data, err := b.MakeRequest(http.MethodGet, "updates", u, nil)
-	if data != nil {
-		defer data.Close()
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, pollTimeout+b.timeout)
+		defer cancel()
 	}
-	if err != nil {
+	var v UpdateList
+	if err := b.callMethod(ctx, http.MethodGet, "updates", u, nil, &v); err != nil {
 		return nil, err
 	}
-	var v UpdateList
-	return &v, json.NewDecoder(data).Decode(&v)
+	return &v, nil
 }
 
 // In case your bot gets data via WebHook, the method returns list of all subscriptions
 func (b *Bot) GetSubscriptions() (*GetSubscriptionsResult, error) {
-	data, err := b.MakeRequest(http.MethodGet, "subscriptions", url.Values{}, nil)
-	if data != nil {
-		defer data.Close()
-	}
-	if err != nil {
+	return b.GetSubscriptionsCtx(context.Background())
+}
+
+// GetSubscriptionsCtx is the context-aware version of GetSubscriptions.
+func (b *Bot) GetSubscriptionsCtx(ctx context.Context) (*GetSubscriptionsResult, error) {
+	var v GetSubscriptionsResult
+	if err := b.callMethod(ctx, http.MethodGet, "subscriptions", url.Values{}, nil, &v); err != nil {
 		return nil, err
 	}
-	var v GetSubscriptionsResult
-	return &v, json.NewDecoder(data).Decode(&v)
+	return &v, nil
 }
 
 // Subscribes bot to receive updates via WebHook. After calling this method, the bot will receive notifications about new events in chat rooms at the specified URL.
 //
 // Your server must be listening on one of the following ports: 80, 8080, 443, 8443, 16384-32383
 func (b *Bot) Subscribe(body SubscriptionRequestBody) (*SimpleQueryResult, error) {
+	return b.SubscribeCtx(context.Background(), body)
+}
+
+// SubscribeCtx is the context-aware version of Subscribe.
+func (b *Bot) SubscribeCtx(ctx context.Context, body SubscriptionRequestBody) (*SimpleQueryResult, error) {
 	bs, err := json.Marshal(body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to encode SubscriptionRequestBody: %w", err)
 	}
-	data, err := b.MakeRequest(http.MethodPost, "subscriptions", url.Values{}, bs)
-	if data != nil {
-		defer data.Close()
-	}
-	if err != nil {
+	var v SimpleQueryResult
+	if err := b.callMethod(ctx, http.MethodPost, "subscriptions", url.Values{}, bs, &v); err != nil {
 		return nil, err
 	}
-	var v SimpleQueryResult
-	return &v, json.NewDecoder(data).Decode(&v)
+	return &v, nil
 }
 
 // Unsubscribes bot from receiving updates via WebHook.
 // After calling the method, the bot stops receiving notifications about new events.
 // Notification via the long-poll API becomes available for the bot
 func (b *Bot) Unsubscribe(webhookUrl string) (*SimpleQueryResult, error) {
+	return b.UnsubscribeCtx(context.Background(), webhookUrl)
+}
+
+// UnsubscribeCtx is the context-aware version of Unsubscribe.
+func (b *Bot) UnsubscribeCtx(ctx context.Context, webhookUrl string) (*SimpleQueryResult, error) {
 	u := url.Values{}
 	u.Add("url", webhookUrl)
-	data, err := b.MakeRequest(http.MethodDelete, "subscriptions", u, nil)
-	if data != nil {
-		defer data.Close()
-	}
-	if err != nil {
+	var v SimpleQueryResult
+	if err := b.callMethod(ctx, http.MethodDelete, "subscriptions", u, nil, &v); err != nil {
 		return nil, err
 	}
-	var v SimpleQueryResult
-	return &v, json.NewDecoder(data).Decode(&v)
+	return &v, nil
 }
 
-func (b *Bot) getUploadUrl(uploadType UploadType) (*UploadEndpoint, error) {
+func (b *Bot) getUploadUrl(ctx context.Context, uploadType UploadType) (*UploadEndpoint, error) {
 	u := url.Values{}
 	u.Add("type", string(uploadType))
-	data, err := b.MakeRequest(http.MethodPost, "uploads", u, nil)
-	if data != nil {
-		defer data.Close()
-	}
-	if err != nil {
+	var v UploadEndpoint
+	if err := b.callMethod(ctx, http.MethodPost, "uploads", u, nil, &v); err != nil {
 		return nil, err
 	}
-	var v UploadEndpoint
-	return &v, json.NewDecoder(data).Decode(&v)
+	return &v, nil
 }
 
 // FileInfo is the struct used to deliver the information of a file to bot.Upload
@@ -552,6 +587,25 @@ type FileInfo struct {
 
 // Returns the URL for the subsequent file upload.
 func (b *Bot) Upload(uploadType UploadType, fileInfo *FileInfo) (Payload, error) {
+	return b.UploadCtx(context.Background(), uploadType, fileInfo)
+}
+
+// UploadCtx is the context-aware version of Upload.
+func (b *Bot) UploadCtx(ctx context.Context, uploadType UploadType, fileInfo *FileInfo) (Payload, error) {
+	var payload Payload
+	switch uploadType {
+	case UploadTypeFile:
+		payload = &FilePayload{}
+	case UploadTypeImage:
+		payload = &ImagePayload{}
+	case UploadTypeVideo:
+		payload = &VideoPayload{}
+	case UploadTypeAudio:
+		payload = &AudioPayload{}
+	default:
+		return nil, fmt.Errorf("failed to upload: Unknown UploadType: %s", uploadType)
+	}
+
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
 
@@ -569,37 +623,23 @@ func (b *Bot) Upload(uploadType UploadType, fileInfo *FileInfo) (Payload, error)
 		return nil, err
 	}
 
-	endpoint, err := b.getUploadUrl(uploadType)
+	endpoint, err := b.getUploadUrl(ctx, uploadType)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, endpoint.Url, body)
-	if err != nil {
-		return nil, err
+	call := &APICall{
+		Method:     "upload",
+		HTTPMethod: http.MethodPost,
+		URL:        endpoint.Url,
+		Header:     http.Header{},
+		Body:       body.Bytes(),
+		Result:     payload,
+		upload:     true,
 	}
-
-	req.Header.Set("Content-Type", writer.FormDataContentType())
-
-	resp, err := b.client.Do(req)
-	if err != nil {
+	call.Header.Set("Content-Type", writer.FormDataContentType())
+	if err := b.invoke(ctx, call); err != nil {
 		return nil, err
 	}
-
-	switch uploadType {
-	case UploadTypeFile:
-		v := FilePayload{}
-		return &v, json.NewDecoder(resp.Body).Decode(&v)
-	case UploadTypeImage:
-		v := ImagePayload{}
-		return &v, json.NewDecoder(resp.Body).Decode(&v)
-	case UploadTypeVideo:
-		v := VideoPayload{}
-		return &v, json.NewDecoder(resp.Body).Decode(&v)
-	case UploadTypeAudio:
-		v := AudioPayload{}
-		return &v, json.NewDecoder(resp.Body).Decode(&v)
-	default:
-		return nil, fmt.Errorf("failed to upload: Unknown UploadType: %s", uploadType)
-	}
+	return payload, nil
 }
@@ -169,6 +169,17 @@ func (*SharePayload) GetPayloadType() string {
 	return "share"
 }
 
+// UnknownPayload is the payload of an attachment type unknown to the library,
+// Raw holds the original JSON of the whole attachment.
+type UnknownPayload struct {
+	Type string
+	Raw  json.RawMessage
+}
+
+func (p *UnknownPayload) GetPayloadType() string {
+	return p.Type
+}
+
 // ButtonsPayload Request to attach buttons.
 type ButtonsPayload struct {
 	Buttons [][]Button `json:"buttons"`
@@ -0,0 +1,115 @@
+package gottbot
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy decides whether a failed request to the Bot API should be attempted again.
+type RetryPolicy interface {
+	// Retry reports whether the request should be retried after its attempt-th failed attempt
+	// and how long to wait before retrying.
+	// resp is nil if the request failed before a response was received.
+	Retry(attempt int, httpMethod string, resp *http.Response, err error) (time.Duration, bool)
+	// Backoff returns the delay to wait after the given number of consecutive failures.
+	Backoff(attempt int) time.Duration
+}
+
+// NoRetry is a RetryPolicy which never retries a request.
+var NoRetry RetryPolicy = &BackoffRetryPolicy{MaxAttempts: 1}
+
+// BackoffRetryPolicy retries the failed requests with exponential backoff and jitter.
+//
+// Requests rejected with 429 Too Many Requests are always retried, honouring the Retry-After header.
+// A request whose Retry-After exceeds MaxBackoff is not retried, its error is returned instead.
+// Network and server errors are only retried for idempotent HTTP methods unless RetryNonIdempotent is set.
+type BackoffRetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts of a request, defaults to 3.
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry, defaults to 500ms.
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between the retries, defaults to 30s.
+	MaxBackoff time.Duration
+	// RetryNonIdempotent allows retrying POST and PATCH requests after network and server errors.
+	RetryNonIdempotent bool
+}
+
+func (p *BackoffRetryPolicy) Retry(attempt int, httpMethod string, resp *http.Response, _ error) (time.Duration, bool) {
+	maxAttempts := p.MaxAttempts
+	if maxAttempts == 0 {
+		maxAttempts = 3
+	}
+	if attempt >= maxAttempts {
+		return 0, false
+	}
+	if resp == nil {
+		return p.Backoff(attempt), p.RetryNonIdempotent || isIdempotent(httpMethod)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if !p.RetryNonIdempotent && !isIdempotent(httpMethod) {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+	if delay, ok := retryAfter(resp); ok {
+		return delay, delay <= p.maxBackoff()
+	}
+	return p.Backoff(attempt), true
+}
+
+func (p *BackoffRetryPolicy) maxBackoff() time.Duration {
+	if p.MaxBackoff == 0 {
+		return time.Second * 30
+	}
+	return p.MaxBackoff
+}
+
+func (p *BackoffRetryPolicy) Backoff(attempt int) time.Duration {
+	minBackoff, maxBackoff := p.MinBackoff, p.maxBackoff()
+	if minBackoff == 0 {
+		minBackoff = time.Millisecond * 500
+	}
+	if attempt < 1 {
+		attempt = 1
+	}
+	delay := maxBackoff
+	if attempt < 32 {
+		if d := minBackoff << (attempt - 1); d > 0 && d < maxBackoff {
+			delay = d
+		}
+	}
+	// pick a random delay in [delay/2, delay] to avoid retrying in lockstep
+	half := int64(delay / 2)
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
+func isIdempotent(httpMethod string) bool {
+	switch httpMethod {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryAfter parses the Retry-After header, which is either in seconds or an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Second * time.Duration(secs), true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
@@ -0,0 +1,65 @@
+package gottbot
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBackoffRetryPolicyRetryAfter(t *testing.T) {
+	policy := &BackoffRetryPolicy{MaxBackoff: time.Minute}
+	tests := []struct {
+		retryAfter string
+		delay      time.Duration
+		retry      bool
+	}{
+		{retryAfter: "0", delay: 0, retry: true},
+		{retryAfter: "5", delay: 5 * time.Second, retry: true},
+		{retryAfter: "60", delay: time.Minute, retry: true},
+		{retryAfter: "61", retry: false},
+		{retryAfter: "86400", retry: false},
+	}
+	for _, tt := range tests {
+		resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {tt.retryAfter}}}
+		delay, retry := policy.Retry(1, http.MethodPost, resp, nil)
+		if retry != tt.retry || retry && delay != tt.delay {
+			t.Errorf("Retry-After %s: got (%s, %t), want (%s, %t)", tt.retryAfter, delay, retry, tt.delay, tt.retry)
+		}
+	}
+}
+
+func TestLongRetryAfterReturnsTooManyRequests(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.Header().Set("Retry-After", strconv.Itoa(86400))
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"code":"too.many.requests","message":"Too many requests"}`))
+	}))
+	defer server.Close()
+	bot, err := NewBot("token", &BotOpts{APIURL: server.URL, DisableTokenVerification: true, Logger: NopLogger})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := bot.SendMessage(1, "text", nil)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, TooManyRequestsError) {
+			t.Errorf("got error %v, want TooManyRequestsError", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the request is waiting for the Retry-After delay")
+	}
+	if attempts := atomic.LoadInt32(&attempts); attempts != 1 {
+		t.Errorf("made %d attempts, want 1", attempts)
+	}
+}
@@ -2,6 +2,8 @@ package gottbot
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 )
 
 const (
@@ -72,7 +74,15 @@ type AttachmentRequest struct {
 	Payload Payload `json:"payload"`
 }
 
+// MarshalJSON encodes the attachment with the type of its payload,
+// an UnknownPayload is re-emitted verbatim.
 func (a AttachmentRequest) MarshalJSON() ([]byte, error) {
+	switch p := a.Payload.(type) {
+	case nil:
+		return nil, errors.New("failed to marshal attachment: no payload")
+	case *UnknownPayload:
+		return p.Raw, nil
+	}
 	type temp AttachmentRequest
 	v := struct {
 		Type string `json:"type"`
@@ -194,6 +204,11 @@ func (a *AttachmentRequest) UnmarshalJSON(b []byte) error {
 		t.Payload.Description = t.Description
 		t.Payload.ImageUrl = t.ImageUrl
 		a.Payload = t.Payload
+	default:
+		a.Payload = &UnknownPayload{
+			Type: v.Type,
+			Raw:  append(json.RawMessage(nil), b...),
+		}
 	}
 	return nil
 }
@@ -682,27 +697,6 @@ type UpdateList struct {
 	Updates []Update `json:"updates"`
 }
 
-func (p *UpdateList) UnmarshalJSON(b []byte) error {
-	v := struct {
-		Marker  int64             `json:"marker,omitempty"`
-		Updates []json.RawMessage `json:"updates"`
-	}{}
-	err := json.Unmarshal(b, &v)
-	if err != nil {
-		return err
-	}
-	updates := make([]Update, len(v.Updates))
-	for i, r := range v.Updates {
-		update, err := unmarshalUpdate(r)
-		if err != nil {
-			return err
-		}
-		updates[i] = *update
-	}
-	p.Updates = updates
-	return nil
-}
-
 // Update `Update` object represents different types of events that happened in chat.
 type Update struct {
 	Type                       UpdateType
@@ -719,12 +713,112 @@ type Update struct {
 	MessageChatCreated         *MessageChatCreated
 	MessageConstructed         *MessageConstructed
 	MessageConstructionRequest *MessageConstructionRequest
+	// Unknown is set instead of the fields above for the update types unknown to the library.
+	Unknown *RawUpdate
 }
 
 func (u *Update) GetUpdateType() UpdateType {
 	return u.Type
 }
 
+// UnmarshalJSON decodes the update from the wire format, filling the field of its type.
+func (u *Update) UnmarshalJSON(b []byte) error {
+	update, err := unmarshalUpdate(b)
+	if err != nil {
+		return err
+	}
+	*u = *update
+	return nil
+}
+
+// MarshalJSON encodes the update to the wire format, from the field of its type.
+func (u Update) MarshalJSON() ([]byte, error) {
+	switch u.Type {
+	case UpdateTypeMessageCreated:
+		if u.MessageCreated != nil {
+			t := *u.MessageCreated
+			t.UpdateType = u.Type
+			return json.Marshal(&t)
+		}
+	case UpdateTypeMessageCallback:
+		if u.MessageCallback != nil {
+			t := *u.MessageCallback
+			t.UpdateType = u.Type
+			return json.Marshal(&t)
+		}
+	case UpdateTypeMessageEdited:
+		if u.MessageEdited != nil {
+			t := *u.MessageEdited
+			t.UpdateType = u.Type
+			return json.Marshal(&t)
+		}
+	case UpdateTypeMessageRemoved:
+		if u.MessageRemoved != nil {
+			t := *u.MessageRemoved
+			t.UpdateType = u.Type
+			return json.Marshal(&t)
+		}
+	case UpdateTypeBotStarted:
+		if u.BotStarted != nil {
+			t := *u.BotStarted
+			t.UpdateType = u.Type
+			return json.Marshal(&t)
+		}
+	case UpdateTypeBotAdded:
+		if u.BotAdded != nil {
+			t := *u.BotAdded
+			t.UpdateType = u.Type
+			return json.Marshal(&t)
+		}
+	case UpdateTypeBotRemoved:
+		if u.BotRemoved != nil {
+			t := *u.BotRemoved
+			t.UpdateType = u.Type
+			return json.Marshal(&t)
+		}
+	case UpdateTypeUserAdded:
+		if u.UserAdded != nil {
+			t := *u.UserAdded
+			t.UpdateType = u.Type
+			return json.Marshal(&t)
+		}
+	case UpdateTypeUserRemoved:
+		if u.UserRemoved != nil {
+			t := *u.UserRemoved
+			t.UpdateType = u.Type
+			return json.Marshal(&t)
+		}
+	case UpdateTypeChatTitleChanged:
+		if u.ChatTitleChanged != nil {
+			t := *u.ChatTitleChanged
+			t.UpdateType = u.Type
+			return json.Marshal(&t)
+		}
+	case UpdateTypeMessageChatCreated:
+		if u.MessageChatCreated != nil {
+			t := *u.MessageChatCreated
+			t.UpdateType = u.Type
+			return json.Marshal(&t)
+		}
+	case UpdateTypeMessageConstructed:
+		if u.MessageConstructed != nil {
+			t := *u.MessageConstructed
+			t.UpdateType = u.Type
+			return json.Marshal(&t)
+		}
+	case UpdateTypeMessageConstructionRequest:
+		if u.MessageConstructionRequest != nil {
+			t := *u.MessageConstructionRequest
+			t.UpdateType = u.Type
+			return json.Marshal(&t)
+		}
+	}
+	if u.Unknown != nil {
+		return u.Unknown.Raw, nil
+	}
+	return nil, fmt.Errorf("failed to marshal update: no payload for update type %q", u.Type)
+}
+
 func unmarshalUpdate(r json.RawMessage) (*Update, error) {
 	v := struct {
 		UpdateType UpdateType `json:"update_type"`
@@ -827,6 +921,14 @@ func unmarshalUpdate(r json.RawMessage) (*Update, error) {
 			return nil, err
 		}
 		update.MessageConstructionRequest = &t
+	default:
+		t := RawUpdate{}
+		err := json.Unmarshal(r, &t)
+		if err != nil {
+			return nil, err
+		}
+		t.Raw = append(json.RawMessage(nil), r...)
+		update.Unknown = &t
 	}
 	return update, nil
 }
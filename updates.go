@@ -1,5 +1,7 @@
 package gottbot
 
+import "encoding/json"
+
 //	type update interface {
 //		GetUpdateType() UpdateType
 //	}
@@ -265,3 +267,17 @@ type MessageChatCreated struct {
 func (*MessageChatCreated) GetUpdateType() UpdateType {
 	return UpdateTypeMessageChatCreated
 }
+
+// RawUpdate is an update of a type unknown to the library, Raw holds its original JSON.
+type RawUpdate struct {
+	// Timestamp Unix-time when event has occurred
+	Timestamp int64 `json:"timestamp"`
+
+	UpdateType UpdateType `json:"update_type"`
+
+	Raw json.RawMessage `json:"-"`
+}
+
+func (r *RawUpdate) GetUpdateType() UpdateType {
+	return r.UpdateType
+}